@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	_ "time/tzdata"
 
 	"github.com/v1adhope/time-tracker/internal/app"
 	"github.com/v1adhope/time-tracker/internal/configs"
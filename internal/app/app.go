@@ -6,11 +6,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/v1adhope/time-tracker/internal/configs"
 	v1 "github.com/v1adhope/time-tracker/internal/controllers/v1"
+	"github.com/v1adhope/time-tracker/internal/entities"
 	"github.com/v1adhope/time-tracker/internal/usecases"
 	"github.com/v1adhope/time-tracker/internal/usecases/repositories"
 	"github.com/v1adhope/time-tracker/pkg/httpserver"
 	"github.com/v1adhope/time-tracker/pkg/logger"
 	"github.com/v1adhope/time-tracker/pkg/postgresql"
+	"github.com/v1adhope/time-tracker/pkg/token"
 )
 
 func Run(cfg *configs.Config, log logger.Logger) error {
@@ -34,7 +36,30 @@ func Run(cfg *configs.Config, log logger.Logger) error {
 
 	repos := repositories.New(postgres)
 
-	usecases := usecases.New(repos)
+	var tokens usecases.TokenManager
+
+	if cfg.Gin.AuthEnabled {
+		manager, err := token.New(&cfg.Auth)
+		if err != nil {
+			return err
+		}
+
+		tokens = manager
+	}
+
+	usecases := usecases.New(repos, cfg.Usecases, tokens)
+
+	if cfg.Gin.AuthEnabled {
+		if err := usecases.Auth.Bootstrap(mainCtx, cfg.Usecases.AuthAdminPassport, entities.Credentials{
+			Login:    cfg.Usecases.AuthAdminLogin,
+			Password: cfg.Usecases.AuthAdminPassword,
+		}); err != nil {
+			return err
+		}
+		log.Info("auth admin was provisioned")
+	} else {
+		log.Warn("auth is disabled, every /v1 endpoint is open to anyone, set APP_AUTH_ENABLED=true")
+	}
 
 	if err := v1.RegisterCustomValidations(); err != nil {
 		return err
@@ -47,6 +72,7 @@ func Run(cfg *configs.Config, log logger.Logger) error {
 		Handler:  handler,
 		Usecases: usecases,
 		Log:      log,
+		Config:   cfg.Gin,
 	})
 
 	httpserver.New(handler, &cfg.Server).Run()
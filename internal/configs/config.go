@@ -7,9 +7,11 @@ import (
 	"github.com/knadh/koanf/providers/file"
 	"github.com/knadh/koanf/v2"
 	v1 "github.com/v1adhope/time-tracker/internal/controllers/v1"
+	"github.com/v1adhope/time-tracker/internal/usecases"
 	"github.com/v1adhope/time-tracker/pkg/httpserver"
 	"github.com/v1adhope/time-tracker/pkg/logger"
 	"github.com/v1adhope/time-tracker/pkg/postgresql"
+	"github.com/v1adhope/time-tracker/pkg/token"
 )
 
 type Config struct {
@@ -17,6 +19,8 @@ type Config struct {
 	Server   httpserver.Config
 	Logger   logger.Config
 	Gin      v1.Config
+	Usecases usecases.Config
+	Auth     token.Config
 }
 
 func Build(path string) (*Config, error) {
@@ -44,5 +48,13 @@ func Build(path string) (*Config, error) {
 		return nil, fmt.Errorf("config unmarshal: gin: %w", err)
 	}
 
+	if err := k.Unmarshal("", &cfg.Usecases); err != nil {
+		return nil, fmt.Errorf("config unmarshal: usecases: %w", err)
+	}
+
+	if err := k.Unmarshal("", &cfg.Auth); err != nil {
+		return nil, fmt.Errorf("config unmarshal: auth: %w", err)
+	}
+
 	return &cfg, nil
 }
@@ -0,0 +1,194 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/v1adhope/time-tracker/internal/entities"
+	"github.com/v1adhope/time-tracker/internal/usecases"
+)
+
+const (
+	authSchemeBearer   = "Bearer"
+	calendarTokenQuery = "token"
+)
+
+type authRouter struct {
+	handler     *gin.RouterGroup
+	protected   *gin.RouterGroup
+	authUsecase usecases.Auth
+	authEnabled bool
+}
+
+func handleAuth(router *authRouter) {
+	// There are no tokens to issue while auth is disabled
+	if router.authEnabled {
+		auth := router.handler.Group("/auth")
+		{
+			auth.POST("/login", router.Login)
+		}
+	}
+
+	protected := router.protected.Group("/auth")
+	{
+		protected.PUT("/credentials/:userId", router.SetCredentials)
+
+		if router.authEnabled {
+			protected.POST("/calendar-token", router.CalendarToken)
+		}
+	}
+}
+
+// authHandler rejects requests without a valid bearer token and puts ID of the token owner into request context.
+func authHandler(authUsecase usecases.Auth) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scheme, token, ok := strings.Cut(c.GetHeader(HeaderAuthorization), " ")
+		if !ok || !strings.EqualFold(scheme, authSchemeBearer) || token == "" {
+			setAnyError(c, entities.ErrorUnauthorized)
+			c.Abort()
+			return
+		}
+
+		id, err := authUsecase.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			setAnyError(c, err)
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(usecases.WithUserID(c.Request.Context(), id))
+
+		c.Next()
+	}
+}
+
+// calendarAuthHandler is authHandler for calendar feeds, clients can't send headers so the token is in query.
+func calendarAuthHandler(authUsecase usecases.Auth) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.Query(calendarTokenQuery)
+		if token == "" {
+			setAnyError(c, entities.ErrorUnauthorized)
+			c.Abort()
+			return
+		}
+
+		id, err := authUsecase.AuthenticateCalendar(c.Request.Context(), token)
+		if err != nil {
+			setAnyError(c, err)
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(usecases.WithUserID(c.Request.Context(), id))
+
+		c.Next()
+	}
+}
+
+type loginReq struct {
+	Login    string `json:"login" binding:"required" example:"ivanov"`
+	Password string `json:"password" binding:"required" example:"correct horse battery staple"`
+}
+
+// @tags auth
+// @summary Login
+// @description Exchange credentials for a signed access token
+// @accept json
+// @produce json
+// @param credentials body loginReq true "Credentials request model"
+// @success 200 {object} entities.Token
+// @response 400
+// @response 401
+// @response 500
+// @router /auth/login [post]
+func (r *authRouter) Login(c *gin.Context) {
+	req := loginReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	token, err := r.authUsecase.Login(c.Request.Context(), entities.Credentials{
+		Login:    req.Login,
+		Password: req.Password,
+	})
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+type setCredentialsReqParams struct {
+	UserID string `uri:"userId" binding:"required,uuid"`
+}
+
+type setCredentialsReq struct {
+	Login    string `json:"login" binding:"required,max=255" example:"ivanov"`
+	Password string `json:"password" binding:"required,min=8,max=72" example:"correct horse battery staple"`
+}
+
+// @tags auth
+// @summary Set user credentials
+// @description Replaces login and password of the user if there are any.
+// @description Only the user itself or an admin may set them
+// @security BearerAuth
+// @accept json
+// @param userId path string true "User id (uuid)"
+// @param credentials body setCredentialsReq true "Credentials request model"
+// @response 200
+// @response 204 "There's no user to set credentials for"
+// @response 400
+// @response 401
+// @response 403
+// @response 500
+// @router /auth/credentials/{userId} [put]
+func (r *authRouter) SetCredentials(c *gin.Context) {
+	params := setCredentialsReqParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	req := setCredentialsReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	if err := r.authUsecase.SetCredentials(c.Request.Context(), entities.Credentials{
+		UserID:   params.UserID,
+		Login:    req.Login,
+		Password: req.Password,
+	}); err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @tags auth
+// @summary Issue calendar token
+// @description Signs a token for the calendar feed of the authenticated user, pass it as token query parameter.
+// @description It doesn't expire, changing credentials revokes it
+// @security BearerAuth
+// @produce json
+// @success 200 {object} entities.CalendarToken
+// @response 401
+// @response 500
+// @router /auth/calendar-token [post]
+func (r *authRouter) CalendarToken(c *gin.Context) {
+	token, err := r.authUsecase.IssueCalendarToken(c.Request.Context())
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
@@ -0,0 +1,472 @@
+package v1_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/v1adhope/time-tracker/internal/configs"
+	"github.com/v1adhope/time-tracker/internal/entities"
+	"github.com/v1adhope/time-tracker/pkg/postgresql"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type credentials struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
+func withAuth(cfg *configs.Config) {
+	cfg.Gin.AuthEnabled = true
+}
+
+func login(handler *gin.Engine, creds credentials) *httptest.ResponseRecorder {
+	bytesBody, _ := json.Marshal(&creds)
+	req, _ := http.NewRequest("POST", "/v1/auth/login", strings.NewReader(string(bytesBody)))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	return recorder
+}
+
+func TestAuthSetCredentials(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	testCases := []struct {
+		key         string
+		userID      string
+		credentials credentials
+		code        int
+	}{
+		{
+			key:    "case 1",
+			userID: getUserID(postgres, 0),
+			credentials: credentials{
+				Login:    "funk",
+				Password: "theresia-secret",
+			},
+			code: http.StatusOK,
+		},
+		{
+			key:    "replace",
+			userID: getUserID(postgres, 0),
+			credentials: credentials{
+				Login:    "tfunk",
+				Password: "theresia-secret-2",
+			},
+			code: http.StatusOK,
+		},
+		{
+			key:    "login is taken",
+			userID: getUserID(postgres, 1),
+			credentials: credentials{
+				Login:    "tfunk",
+				Password: "violette-secret",
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			key:    "short password",
+			userID: getUserID(postgres, 1),
+			credentials: credentials{
+				Login:    "violette",
+				Password: "short",
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			key:    "unknown user",
+			userID: "1ef4f189-7b2a-6740-a609-370ed63a9fc7",
+			credentials: credentials{
+				Login:    "ghost",
+				Password: "ghost-secret",
+			},
+			code: http.StatusNoContent,
+		},
+		{
+			key:    "invalid user id",
+			userID: "1",
+			credentials: credentials{
+				Login:    "ghost",
+				Password: "ghost-secret",
+			},
+			code: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			bytesBody, _ := json.Marshal(&tc.credentials)
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("/v1/auth/credentials/%s", tc.userID), strings.NewReader(string(bytesBody)))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.code, recorder.Code, tc.key)
+		})
+	}
+}
+
+func seedingCredentials(postgres *postgresql.Postgres, userID string, creds credentials, isAdmin bool) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte(creds.Password), bcrypt.MinCost)
+
+	sql, args, _ := postgres.Builder.Insert("credentials").
+		Columns("user_id", "login", "password_hash", "is_admin").
+		Values(userID, creds.Login, string(hash), isAdmin).
+		ToSql()
+
+	postgres.Pool.Exec(context.Background(), sql, args...)
+}
+
+func TestAuthLogin(t *testing.T) {
+	postgres, handler := prepareWith(withAuth)
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	seedingCredentials(postgres, getUserID(postgres, 0), credentials{
+		Login:    "funk",
+		Password: "theresia-secret",
+	}, false)
+
+	testCases := []struct {
+		key         string
+		credentials credentials
+		code        int
+	}{
+		{
+			key: "case 1",
+			credentials: credentials{
+				Login:    "funk",
+				Password: "theresia-secret",
+			},
+			code: http.StatusOK,
+		},
+		{
+			key: "unknown login",
+			credentials: credentials{
+				Login:    "nobody",
+				Password: "theresia-secret",
+			},
+			code: http.StatusUnauthorized,
+		},
+		{
+			key: "wrong password",
+			credentials: credentials{
+				Login:    "funk",
+				Password: "wrong-secret",
+			},
+			code: http.StatusUnauthorized,
+		},
+		{
+			key: "without password",
+			credentials: credentials{
+				Login: "funk",
+			},
+			code: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			recorder := login(handler, tc.credentials)
+
+			assert.Equal(t, tc.code, recorder.Code, tc.key)
+
+			if tc.code != http.StatusOK {
+				return
+			}
+
+			token := entities.Token{}
+			json.Unmarshal(recorder.Body.Bytes(), &token)
+
+			assert.NotEmpty(t, token.AccessToken, tc.key)
+			assert.Equal(t, "Bearer", token.TokenType, tc.key)
+			assert.NotEmpty(t, token.ExpiresAt, tc.key)
+		})
+	}
+}
+
+func TestAuthProtected(t *testing.T) {
+	postgres, handler := prepareWith(withAuth)
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	creds := credentials{
+		Login:    "funk",
+		Password: "theresia-secret",
+	}
+
+	seedingCredentials(postgres, getUserID(postgres, 0), creds, false)
+
+	token := entities.Token{}
+	json.Unmarshal(login(handler, creds).Body.Bytes(), &token)
+
+	testCases := []struct {
+		key           string
+		authorization string
+		code          int
+	}{
+		{
+			key:           "case 1",
+			authorization: fmt.Sprintf("Bearer %s", token.AccessToken),
+			code:          http.StatusOK,
+		},
+		{
+			key:  "without token",
+			code: http.StatusUnauthorized,
+		},
+		{
+			key:           "garbage token",
+			authorization: "Bearer garbage",
+			code:          http.StatusUnauthorized,
+		},
+		{
+			key:           "wrong scheme",
+			authorization: fmt.Sprintf("Basic %s", token.AccessToken),
+			code:          http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/v1/users/", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.code, recorder.Code, tc.key)
+
+			if tc.code == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", recorder.Header().Get("WWW-Authenticate"), tc.key)
+			}
+		})
+	}
+}
+
+func TestAuthSetCredentialsOwnership(t *testing.T) {
+	postgres, handler := prepareWith(withAuth)
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	userCreds := credentials{
+		Login:    "funk",
+		Password: "theresia-secret",
+	}
+	adminCreds := credentials{
+		Login:    "admin",
+		Password: "admin-secret",
+	}
+
+	seedingCredentials(postgres, getUserID(postgres, 0), userCreds, false)
+	seedingCredentials(postgres, getUserID(postgres, 1), adminCreds, true)
+
+	userToken := entities.Token{}
+	json.Unmarshal(login(handler, userCreds).Body.Bytes(), &userToken)
+
+	adminToken := entities.Token{}
+	json.Unmarshal(login(handler, adminCreds).Body.Bytes(), &adminToken)
+
+	testCases := []struct {
+		key         string
+		token       string
+		userID      string
+		credentials credentials
+		code        int
+	}{
+		{
+			key:    "credentials of another user",
+			token:  userToken.AccessToken,
+			userID: getUserID(postgres, 2),
+			credentials: credentials{
+				Login:    "jessie",
+				Password: "jessie-secret",
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			key:    "admin sets credentials of another user",
+			token:  adminToken.AccessToken,
+			userID: getUserID(postgres, 2),
+			credentials: credentials{
+				Login:    "jessie",
+				Password: "jessie-secret",
+			},
+			code: http.StatusOK,
+		},
+		// Goes last, the new credentials revoke the user token
+		{
+			key:    "own credentials",
+			token:  userToken.AccessToken,
+			userID: getUserID(postgres, 0),
+			credentials: credentials{
+				Login:    "tfunk",
+				Password: "theresia-secret-2",
+			},
+			code: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			bytesBody, _ := json.Marshal(&tc.credentials)
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("/v1/auth/credentials/%s", tc.userID), strings.NewReader(string(bytesBody)))
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tc.token))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.code, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestAuthRevoke(t *testing.T) {
+	postgres, handler := prepareWith(withAuth)
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	userID := getUserID(postgres, 0)
+
+	creds := credentials{
+		Login:    "funk",
+		Password: "theresia-secret",
+	}
+
+	seedingCredentials(postgres, userID, creds, false)
+
+	get := func(token string) int {
+		req, _ := http.NewRequest("GET", "/v1/users/", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		return recorder.Code
+	}
+
+	oldToken := entities.Token{}
+	json.Unmarshal(login(handler, creds).Body.Bytes(), &oldToken)
+
+	t.Run("password change", func(t *testing.T) {
+		creds.Password = "theresia-secret-2"
+		bytesBody, _ := json.Marshal(&creds)
+		req, _ := http.NewRequest("PUT", fmt.Sprintf("/v1/auth/credentials/%s", userID), strings.NewReader(string(bytesBody)))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", oldToken.AccessToken))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, http.StatusUnauthorized, get(oldToken.AccessToken))
+	})
+
+	newToken := entities.Token{}
+	json.Unmarshal(login(handler, creds).Body.Bytes(), &newToken)
+
+	t.Run("user deletion", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, get(newToken.AccessToken))
+
+		req, _ := http.NewRequest("DELETE", fmt.Sprintf("/v1/users/%s", userID), nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", newToken.AccessToken))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, http.StatusUnauthorized, get(newToken.AccessToken))
+	})
+}
+
+func TestAuthCalendar(t *testing.T) {
+	postgres, handler := prepareWith(withAuth)
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	creds := credentials{
+		Login:    "funk",
+		Password: "theresia-secret",
+	}
+
+	seedingCredentials(postgres, getUserID(postgres, 2), creds, false)
+
+	accessToken := entities.Token{}
+	json.Unmarshal(login(handler, creds).Body.Bytes(), &accessToken)
+
+	req, _ := http.NewRequest("POST", "/v1/auth/calendar-token", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken.AccessToken))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	calendarToken := entities.CalendarToken{}
+	json.Unmarshal(recorder.Body.Bytes(), &calendarToken)
+
+	testCases := []struct {
+		key           string
+		userID        string
+		token         string
+		authorization string
+		code          int
+	}{
+		{
+			key:    "case 1",
+			userID: getUserID(postgres, 2),
+			token:  calendarToken.Token,
+			code:   http.StatusOK,
+		},
+		{
+			key:    "without token",
+			userID: getUserID(postgres, 2),
+			code:   http.StatusUnauthorized,
+		},
+		{
+			key:           "bearer token in header",
+			userID:        getUserID(postgres, 2),
+			authorization: fmt.Sprintf("Bearer %s", accessToken.AccessToken),
+			code:          http.StatusUnauthorized,
+		},
+		{
+			key:    "access token in query",
+			userID: getUserID(postgres, 2),
+			token:  accessToken.AccessToken,
+			code:   http.StatusUnauthorized,
+		},
+		{
+			key:    "feed of another user",
+			userID: getUserID(postgres, 3),
+			token:  calendarToken.Token,
+			code:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			query := url.Values{}
+			if tc.token != "" {
+				query.Set("token", tc.token)
+			}
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/calendar/%s.ics?%s", tc.userID, query.Encode()), nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.code, recorder.Code, tc.key)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package v1
+
+import "encoding/base64"
+
+// encodeCursor hides the id, so what's inside of a cursor can change without breaking clients.
+func encodeCursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+// decodeCursor expects the cursor is validated already.
+func decodeCursor(cursor string) string {
+	id, _ := base64.RawURLEncoding.DecodeString(cursor)
+
+	return string(id)
+}
@@ -35,17 +35,48 @@ func errorHandler(log logger.Logger) gin.HandlerFunc {
 				return
 			case gin.ErrorTypeAny:
 				switch {
-				case errors.Is(ginErr.Err, entities.ErrorUserHasAlreadyExistWithThatPassport):
+				case errors.Is(ginErr.Err, entities.ErrorUserHasAlreadyExistWithThatPassport),
+					errors.Is(ginErr.Err, entities.ErrorProjectHasAlreadyExistWithThatName),
+					errors.Is(ginErr.Err, entities.ErrorCredentialsHasAlreadyExistWithThatLogin),
+					errors.Is(ginErr.Err, entities.ErrorTaskInvalidTimeRange),
+					errors.Is(ginErr.Err, entities.ErrorTaskInFuture),
+					errors.Is(ginErr.Err, entities.ErrorTaskCursorWithoutID),
+					errors.Is(ginErr.Err, entities.ErrorTaskDurationFormatCSV),
+					errors.Is(ginErr.Err, entities.ErrorNothingToUpdate):
+
 					log.Debug(ginErr.Err)
 					abortWithStatusMSG(c, http.StatusBadRequest, ginErr.Err.Error())
 					return
 				case errors.Is(ginErr.Err, entities.ErrorUsersDoesNotExist),
 					errors.Is(ginErr.Err, entities.ErrorTaskDoesNotExist),
-					errors.Is(ginErr.Err, entities.ErrorNoAnyTasksForThisUser):
+					errors.Is(ginErr.Err, entities.ErrorNoAnyTasksForThisUser),
+					errors.Is(ginErr.Err, entities.ErrorProjectsDoesNotExist),
+					errors.Is(ginErr.Err, entities.ErrorNoAnyTaskOverlaps):
 
 					log.Debug(ginErr.Err)
 					c.AbortWithStatus(http.StatusNoContent)
 					return
+				case errors.Is(ginErr.Err, entities.ErrorTaskAlreadyFinished),
+					errors.Is(ginErr.Err, entities.ErrorTaskHasAlreadyPaused),
+					errors.Is(ginErr.Err, entities.ErrorTaskIsNotPaused),
+					errors.Is(ginErr.Err, entities.ErrorUserHasRunningTask),
+					errors.Is(ginErr.Err, entities.ErrorTaskIsRunning),
+					errors.Is(ginErr.Err, entities.ErrorTaskOverlaps):
+
+					log.Debug(ginErr.Err)
+					abortWithStatusMSG(c, http.StatusConflict, ginErr.Err.Error())
+					return
+				case errors.Is(ginErr.Err, entities.ErrorInvalidCredentials),
+					errors.Is(ginErr.Err, entities.ErrorUnauthorized):
+
+					log.Debug(ginErr.Err)
+					c.Header(HeaderWWWAuthenticate, authSchemeBearer)
+					abortWithStatusMSG(c, http.StatusUnauthorized, ginErr.Err.Error())
+					return
+				case errors.Is(ginErr.Err, entities.ErrorForbidden):
+					log.Debug(ginErr.Err)
+					abortWithStatusMSG(c, http.StatusForbidden, ginErr.Err.Error())
+					return
 				case errors.Is(ginErr.Err, entities.ErrorUserDoesNotExistWithThatPassportInfoExeption):
 					log.Debug(ginErr.Err)
 					abortWithStatusMSG(c, http.StatusBadRequest, ginErr.Err.Error())
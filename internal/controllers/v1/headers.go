@@ -2,12 +2,19 @@ package v1
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 const (
-	HeaderLocation = "Location"
+	HeaderLocation           = "Location"
+	HeaderContentDisposition = "Content-Disposition"
+	HeaderTotalCount         = "X-Total-Count"
+	HeaderNextCursor         = "X-Next-Cursor"
+	HeaderLink               = "Link"
+	HeaderAuthorization      = "Authorization"
+	HeaderWWWAuthenticate    = "WWW-Authenticate"
 )
 
 func parseBaseReqURL(c *gin.Context) string {
@@ -43,3 +50,31 @@ func setTaskLocationHeader(c *gin.Context, id string) {
 
 	setLocationHeader(c, location)
 }
+
+func setProjectLocationHeader(c *gin.Context, id string) {
+	location := fmt.Sprintf(
+		"%s/v1/projects/%s",
+		parseBaseReqURL(c),
+		id,
+	)
+
+	setLocationHeader(c, location)
+}
+
+func setAttachmentHeader(c *gin.Context, filename string) {
+	c.Header(HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+}
+
+func setTotalCountHeader(c *gin.Context, total int) {
+	c.Header(HeaderTotalCount, strconv.Itoa(total))
+}
+
+// setNextPageHeaders links the same request with the next cursor, offset is dropped since it can't go along.
+func setNextPageHeaders(c *gin.Context, cursor string) {
+	query := c.Request.URL.Query()
+	query.Set("cursor", cursor)
+	query.Del("offset")
+
+	c.Header(HeaderNextCursor, cursor)
+	c.Header(HeaderLink, fmt.Sprintf(`<%s%s?%s>; rel="next"`, parseBaseReqURL(c), c.Request.URL.Path, query.Encode()))
+}
@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/v1adhope/time-tracker/internal/entities"
+)
+
+const (
+	MIMECalendar = "text/calendar; charset=utf-8"
+
+	icsLineLimit  = 75
+	icsTimeLayout = "20060102T150405Z"
+)
+
+var icsTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// renderCalendar renders finished tasks as RFC 5545 VEVENTs, running tasks are omitted.
+func renderCalendar(tasks []entities.TaskSummary) ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	writeICSLine(&buf, "BEGIN:VCALENDAR")
+	writeICSLine(&buf, "VERSION:2.0")
+	writeICSLine(&buf, "PRODID:-//time-tracker//tasks//EN")
+	writeICSLine(&buf, "CALSCALE:GREGORIAN")
+
+	for _, task := range tasks {
+		if task.FinishedAt == "" {
+			continue
+		}
+
+		createdAt, err := formatICSTime(task.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		finishedAt, err := formatICSTime(task.FinishedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		writeICSLine(&buf, "BEGIN:VEVENT")
+		writeICSLine(&buf, "UID:"+task.ID)
+		writeICSLine(&buf, "DTSTAMP:"+finishedAt)
+		writeICSLine(&buf, "DTSTART:"+createdAt)
+		writeICSLine(&buf, "DTEND:"+finishedAt)
+		writeICSLine(&buf, "SUMMARY:"+icsTextEscaper.Replace(task.Title))
+
+		if task.Description != "" {
+			writeICSLine(&buf, "DESCRIPTION:"+icsTextEscaper.Replace(task.Description))
+		}
+
+		if len(task.Labels) != 0 {
+			labels := make([]string, 0, len(task.Labels))
+
+			for _, label := range task.Labels {
+				labels = append(labels, icsTextEscaper.Replace(label))
+			}
+
+			writeICSLine(&buf, "CATEGORIES:"+strings.Join(labels, ","))
+		}
+
+		writeICSLine(&buf, "END:VEVENT")
+	}
+
+	writeICSLine(&buf, "END:VCALENDAR")
+
+	return buf.Bytes(), nil
+}
+
+func formatICSTime(target string) (string, error) {
+	value, err := time.Parse(time.RFC3339, target)
+	if err != nil {
+		return "", fmt.Errorf("v1: formatICSTime: parse: %w", err)
+	}
+
+	return value.UTC().Format(icsTimeLayout), nil
+}
+
+// writeICSLine folds content lines longer than 75 octets without splitting utf-8 sequences.
+func writeICSLine(buf *bytes.Buffer, line string) {
+	limit := icsLineLimit
+
+	for len(line) > limit {
+		cut := limit
+
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icsLineLimit - 1
+	}
+
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,192 @@
+package v1
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/v1adhope/time-tracker/internal/entities"
+)
+
+const (
+	MIMEPDF = "application/pdf"
+
+	pdfFont       = "DejaVuSansCondensed"
+	pdfRowHeight  = 7.0
+	pdfDateLayout = "2006-01-02"
+	pdfTimeLayout = "2006-01-02 15:04"
+)
+
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	pdfFontRegular []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	pdfFontBold []byte
+)
+
+var timesheetPDFColumns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{"Date", 22, "L"},
+	{"Started", 30, "L"},
+	{"Finished", 30, "L"},
+	{"Title", 83, "L"},
+	{"Duration", 25, "R"},
+}
+
+// renderTimesheetPDF renders a printable timesheet, tasks are grouped by the day they were started
+// and every day is closed by a subtotal row. The embedded UTF-8 font covers names and titles in any script.
+func renderTimesheetPDF(timesheet entities.TaskTimesheet, sort entities.TaskSort) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(pdfFont, "", pdfFontRegular)
+	pdf.AddUTF8FontFromBytes(pdfFont, "B", pdfFontBold)
+
+	fullName := strings.Join([]string{timesheet.User.Surname, timesheet.User.Name, timesheet.User.Patronymic}, " ")
+
+	pdf.SetTitle(fmt.Sprintf("Timesheet %s", fullName), true)
+	pdf.AddPage()
+
+	pdf.SetFont(pdfFont, "B", 16)
+	pdf.CellFormat(0, 10, "Timesheet", "", 1, "L", false, 0, "")
+
+	pdf.SetFont(pdfFont, "", 11)
+	pdf.CellFormat(0, pdfRowHeight, fmt.Sprintf("Employee: %s", fullName), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, pdfRowHeight, fmt.Sprintf("Period: %s - %s", formatPDFPeriodBound(sort.StartTime, "beginning"), formatPDFPeriodBound(sort.EndTime, "now")), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont(pdfFont, "B", 10)
+	pdf.SetFillColor(230, 230, 230)
+
+	for _, column := range timesheetPDFColumns {
+		pdf.CellFormat(column.width, pdfRowHeight, column.title, "1", 0, column.align, true, 0, "")
+	}
+
+	pdf.Ln(-1)
+
+	var (
+		day      string
+		dayTotal time.Duration
+		total    time.Duration
+	)
+
+	for _, task := range timesheet.Tasks {
+		createdAt, err := time.Parse(time.RFC3339, task.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("v1: renderTimesheetPDF: parse createdAt: %w", err)
+		}
+
+		taskDay := createdAt.Format(pdfDateLayout)
+
+		if day != "" && taskDay != day {
+			writePDFTotalRow(pdf, "Day total", dayTotal)
+			dayTotal = 0
+		}
+
+		day = taskDay
+
+		// Zero for a task without closed intervals
+		duration := task.SummaryDuration
+
+		dayTotal += duration
+		total += duration
+
+		finishedAt := "running"
+
+		if task.FinishedAt != "" {
+			value, err := time.Parse(time.RFC3339, task.FinishedAt)
+			if err != nil {
+				return nil, fmt.Errorf("v1: renderTimesheetPDF: parse finishedAt: %w", err)
+			}
+
+			finishedAt = value.Format(pdfTimeLayout)
+		}
+
+		pdf.SetFont(pdfFont, "", 10)
+
+		for i, value := range []string{day, createdAt.Format(pdfTimeLayout), finishedAt, task.Title, formatClockDuration(duration)} {
+			column := timesheetPDFColumns[i]
+			pdf.CellFormat(column.width, pdfRowHeight, fitPDFText(pdf, value, column.width), "1", 0, column.align, false, 0, "")
+		}
+
+		pdf.Ln(-1)
+	}
+
+	if day != "" {
+		writePDFTotalRow(pdf, "Day total", dayTotal)
+	}
+
+	writePDFTotalRow(pdf, fmt.Sprintf("Total, %d tasks", len(timesheet.Tasks)), total)
+
+	pdf.Ln(20)
+	pdf.SetFont(pdfFont, "", 11)
+
+	for _, signer := range []string{"Employee", "Client"} {
+		pdf.CellFormat(60, pdfRowHeight, fmt.Sprintf("%s signature:", signer), "", 0, "L", false, 0, "")
+		pdf.CellFormat(70, pdfRowHeight, "", "B", 0, "L", false, 0, "")
+		pdf.CellFormat(20, pdfRowHeight, "Date:", "", 0, "R", false, 0, "")
+		pdf.CellFormat(40, pdfRowHeight, "", "B", 1, "L", false, 0, "")
+		pdf.Ln(10)
+	}
+
+	buf := bytes.Buffer{}
+
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("v1: renderTimesheetPDF: output: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writePDFTotalRow(pdf *fpdf.Fpdf, title string, total time.Duration) {
+	width := 0.0
+
+	for _, column := range timesheetPDFColumns[:len(timesheetPDFColumns)-1] {
+		width += column.width
+	}
+
+	pdf.SetFont(pdfFont, "B", 10)
+	pdf.CellFormat(width, pdfRowHeight, title, "1", 0, "R", false, 0, "")
+	pdf.CellFormat(timesheetPDFColumns[len(timesheetPDFColumns)-1].width, pdfRowHeight, formatClockDuration(total), "1", 1, "R", false, 0, "")
+}
+
+// formatClockDuration formats duration as hours:minutes the same way spreadsheets show [h]:mm.
+func formatClockDuration(d time.Duration) string {
+	minutes := int64(d.Round(time.Minute) / time.Minute)
+
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+func formatPDFPeriodBound(target, fallback string) string {
+	if target == "" {
+		return fallback
+	}
+
+	value, err := time.Parse(time.RFC3339, target)
+	if err != nil {
+		return target
+	}
+
+	return value.Format(pdfTimeLayout + " -07:00")
+}
+
+// fitPDFText cuts text which doesn't fit into a cell keeping a small padding, whole runes are cut.
+func fitPDFText(pdf *fpdf.Fpdf, text string, width float64) string {
+	const ellipsis = "..."
+
+	if pdf.GetStringWidth(text) <= width-2 {
+		return text
+	}
+
+	runes := []rune(text)
+
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+ellipsis) > width-2 {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes) + ellipsis
+}
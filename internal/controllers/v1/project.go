@@ -0,0 +1,199 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/v1adhope/time-tracker/internal/entities"
+	"github.com/v1adhope/time-tracker/internal/usecases"
+)
+
+type projectRouter struct {
+	handler        *gin.RouterGroup
+	projectUsecase usecases.Project
+}
+
+func handleProject(router *projectRouter) {
+	projects := router.handler.Group("/projects")
+	{
+		projects.POST("/", router.Create)
+		projects.DELETE("/:id", router.Delete)
+		projects.PATCH("/:id", router.Update)
+		projects.GET("/", router.All)
+		projects.GET("/:id", router.Get)
+	}
+}
+
+type createProjectReq struct {
+	Name        string `json:"name" binding:"required,max=255" example:"Acme website"`
+	Description string `json:"description" example:"Landing page redesign"`
+}
+
+// @tags projects
+// @summary Create project
+// @accept json
+// @param project body createProjectReq true "Project request model"
+// @response 201
+// @header 201 {string} Location "Return /v1/projects/:id resource"
+// @response 400
+// @response 500
+// @router /projects [post]
+func (r *projectRouter) Create(c *gin.Context) {
+	req := createProjectReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	id, err := r.projectUsecase.Create(c.Request.Context(), entities.Project{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	setProjectLocationHeader(c, id)
+
+	c.Status(http.StatusCreated)
+}
+
+type deleteProjectReqParams struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// @tags projects
+// @summary Delete project
+// @description Tasks booked against the project are kept without project
+// @param id path string true "Project id (uuid)"
+// @response 200
+// @response 204 "There's no project to delete"
+// @response 400
+// @response 500
+// @router /projects/{id} [delete]
+func (r *projectRouter) Delete(c *gin.Context) {
+	params := deleteProjectReqParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	if err := r.projectUsecase.Delete(c.Request.Context(), params.ID); err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+type updateProjectReqParams struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+type updateProjectReq struct {
+	Name        string `json:"name" binding:"max=255" example:"Acme mobile app"`
+	Description string `json:"description" example:"iOS and Android clients"`
+}
+
+// @tags projects
+// @summary Update project
+// @param id path string true "Project id (uuid)"
+// @accept json
+// @param project body updateProjectReq true "Project request model"
+// @response 200
+// @response 204 "There's no project to change"
+// @response 400 "Invalid or empty request model"
+// @response 500
+// @router /projects/{id} [patch]
+func (r *projectRouter) Update(c *gin.Context) {
+	params := updateProjectReqParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	req := updateProjectReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	if err := r.projectUsecase.Update(c.Request.Context(), entities.Project{
+		ID:          params.ID,
+		Name:        req.Name,
+		Description: req.Description,
+	}); err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+type allProjectQuery struct {
+	Limit  string `form:"limit" binding:"omitempty,number"`
+	Offset string `form:"offset" binding:"omitempty,number"`
+}
+
+// @tags projects
+// @summary Get all projects
+// @param limit query uint64 false "Pagination control"
+// @param offset query uint64 false "Pagination control"
+// @response 200 {object} []entities.Project
+// @response 204 "No any projects by this request"
+// @response 400
+// @response 500
+// @router /projects [get]
+func (r *projectRouter) All(c *gin.Context) {
+	query := allProjectQuery{}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	projects, err := r.projectUsecase.GetAll(c.Request.Context(), entities.ProjectPagination{
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, projects)
+}
+
+type getProjectReqParams struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// @tags projects
+// @summary Get project
+// @param id path string true "Project id (uuid)"
+// @response 200 {object} entities.Project
+// @response 204 "There's no project with that id"
+// @response 400
+// @response 500
+// @router /projects/{id} [get]
+func (r *projectRouter) Get(c *gin.Context) {
+	params := getProjectReqParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	project, err := r.projectUsecase.Get(c.Request.Context(), params.ID)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
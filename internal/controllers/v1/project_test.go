@@ -0,0 +1,447 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectCreatePositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type project struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+	}
+
+	testCases := []struct {
+		key     string
+		project project
+	}{
+		{
+			key: "case 1",
+			project: project{
+				Name:        "Billing service",
+				Description: "Invoices and payments",
+			},
+		},
+		{
+			key: "without description",
+			project: project{
+				Name: "Data warehouse",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			bytesBody, _ := json.Marshal(&tc.project)
+			req, _ := http.NewRequest("POST", "/v1/projects/", strings.NewReader(string(bytesBody)))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusCreated, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestProjectCreateNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type project struct {
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+	}
+
+	testCases := []struct {
+		key   string
+		input project
+	}{
+		{
+			key: "forgot name",
+			input: project{
+				Description: "Invoices and payments",
+			},
+		},
+		{
+			key: "name already exist",
+			input: project{
+				Name: "Acme website",
+			},
+		},
+		{
+			key: "too long name",
+			input: project{
+				Name: strings.Repeat("a", 256),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			bytesBody, _ := json.Marshal(&tc.input)
+			req, _ := http.NewRequest("POST", "/v1/projects/", strings.NewReader(string(bytesBody)))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusBadRequest, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestProjectUpdatePositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type project struct {
+		ID          string `json:"-"`
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+	}
+
+	testCases := []project{
+		{
+			ID:   getProjectID(postgres, 0),
+			Name: "Acme website v2",
+		},
+		{
+			ID:          getProjectID(postgres, 1),
+			Description: "CI and deploy scripts",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.ID, func(t *testing.T) {
+			projectJSON, _ := json.Marshal(&tc)
+			req, _ := http.NewRequest("PATCH", fmt.Sprintf("/v1/projects/%s", tc.ID), strings.NewReader(string(projectJSON)))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.ID)
+		})
+	}
+}
+
+func TestProjectUpdateNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type project struct {
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+	}
+
+	testCases := []struct {
+		key          string
+		id           string
+		project      project
+		expectedCode int
+	}{
+		{
+			key: "there's no project with that id",
+			id:  "1ef44bc9-5c77-6c90-a41a-1f1b0b522ea3",
+			project: project{
+				Name: "Billing service",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "not correct type of id",
+			id:  "1",
+			project: project{
+				Name: "Billing service",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "name already exist",
+			id:  getProjectID(postgres, 0),
+			project: project{
+				Name: "Internal tooling",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key:          "nothing to update",
+			id:           getProjectID(postgres, 0),
+			project:      project{},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			projectJSON, _ := json.Marshal(&tc.project)
+			req, _ := http.NewRequest("PATCH", fmt.Sprintf("/v1/projects/%s", tc.id), strings.NewReader(string(projectJSON)))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestProjectDeletePositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	testCases := []string{
+		getProjectID(postgres, 0),
+		getProjectID(postgres, 1),
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc, func(t *testing.T) {
+			req, _ := http.NewRequest("DELETE", fmt.Sprintf("/v1/projects/%s", tc), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc)
+		})
+	}
+}
+
+func TestProjectDeleteNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	testCases := []struct {
+		key          string
+		id           string
+		expectedCode int
+	}{
+		{
+			key:          "there's no project with that id",
+			id:           "1ef44bc9-5c77-6c90-a41a-1f1b0b522ea3",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key:          "not correct type of id",
+			id:           "5a55-6710-a9cf-ddslfjkjjj2e",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("DELETE", fmt.Sprintf("/v1/projects/%s", tc.id), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestProjectGetAllPositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type project struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	type input struct {
+		limit  string
+		offset string
+	}
+
+	testCases := []struct {
+		key      string
+		input    input
+		expected []project
+	}{
+		{
+			key: "no query test",
+			expected: []project{
+				{
+					Name:        "Acme website",
+					Description: "Landing page redesign",
+				},
+				{
+					Name: "Internal tooling",
+				},
+				{
+					Name:        "Mobile app",
+					Description: "iOS and Android clients",
+				},
+			},
+		},
+		{
+			key: "pagination",
+			input: input{
+				limit:  "1",
+				offset: "1",
+			},
+			expected: []project{
+				{
+					Name: "Internal tooling",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			query := url.Values{}
+
+			query.Set("limit", tc.input.limit)
+			query.Set("offset", tc.input.offset)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/projects/?%s", query.Encode()), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+
+			projects := make([]project, 0)
+			json.NewDecoder(recorder.Body).Decode(&projects)
+			assert.Equal(t, tc.expected, projects, tc.key)
+		})
+	}
+}
+
+func TestProjectGetAllNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type input struct {
+		limit  string
+		offset string
+	}
+
+	testCases := []struct {
+		key          string
+		input        input
+		expectedCode int
+	}{
+		{
+			key: "with limit 0",
+			input: input{
+				limit: "0",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "offset not uint64",
+			input: input{
+				offset: "one",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			query := url.Values{}
+
+			query.Set("limit", tc.input.limit)
+			query.Set("offset", tc.input.offset)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/projects/?%s", query.Encode()), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestProjectGetPositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type project struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+
+	testCases := []struct {
+		key      string
+		id       string
+		expected project
+	}{
+		{
+			key: "case 1",
+			id:  getProjectID(postgres, 0),
+			expected: project{
+				Name:        "Acme website",
+				Description: "Landing page redesign",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/projects/%s", tc.id), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+
+			project := project{}
+			json.NewDecoder(recorder.Body).Decode(&project)
+			assert.Equal(t, tc.expected, project, tc.key)
+		})
+	}
+}
+
+func TestProjectGetNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	testCases := []struct {
+		key          string
+		id           string
+		expectedCode int
+	}{
+		{
+			key:          "there's no project with that id",
+			id:           "1ef44bc9-5c77-6c90-a41a-1f1b0b522ea3",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key:          "not correct type of id",
+			id:           "2",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/projects/%s", tc.id), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
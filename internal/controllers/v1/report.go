@@ -0,0 +1,202 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/v1adhope/time-tracker/internal/entities"
+	"github.com/v1adhope/time-tracker/internal/usecases"
+)
+
+type reportRouter struct {
+	handler     *gin.RouterGroup
+	taskUsecase usecases.Task
+}
+
+func handleReport(router *reportRouter) {
+	reports := router.handler.Group("/reports")
+	{
+		reports.GET("/summary", router.Summary)
+		reports.GET("/timesheet/:userId", router.Timesheet)
+	}
+}
+
+type summaryReqQuery struct {
+	ID             string          `form:"id" binding:"omitempty,uuid"`
+	Surname        entities.Filter `form:"surname"`
+	Name           entities.Filter `form:"name"`
+	Patronymic     entities.Filter `form:"patronymic"`
+	Address        entities.Filter `form:"address"`
+	PassportNumber entities.Filter `form:"passportNumber"`
+
+	StartTime string `form:"startTime" binding:"omitempty,sorttime"`
+	EndTime   string `form:"endTime" binding:"omitempty,sorttime"`
+	ProjectID string `form:"projectId" binding:"omitempty,uuid"`
+	Timezone  string `form:"tz" binding:"omitempty,timezone"`
+	Mode      string `form:"mode" binding:"omitempty,oneof=overlap contained"`
+	Order     string `form:"order" binding:"omitempty,oneof=asc desc"`
+	Format    string `form:"format" binding:"omitempty,oneof=json xlsx"`
+
+	Limit  string `form:"limit" binding:"omitempty,number"`
+	Offset string `form:"offset" binding:"omitempty,number"`
+}
+
+// @tags reports
+// @summary Get team summary time
+// @description Total tracked time per user, users without tasks in the range are returned with zero tasksCount
+// @param id query string false "Find by user id (uuid)"
+// @param surname query string false "Custom type consitst operation:value. Allowed operations eq, neq, in (comma list), prefix, suffix, ilike, notilike"
+// @param name query string false "Custom type consitst operation:value. Allowed operations eq, neq, in (comma list), prefix, suffix, ilike, notilike"
+// @param patronymic query string false "Custom type consitst operation:value. Allowed operations eq, neq, in (comma list), prefix, suffix, ilike, notilike"
+// @param address query string false "Custom type consitst operation:value. Allowed operations eq, neq, in (comma list), prefix, suffix, ilike, notilike"
+// @param passportNumber query string false "Custom type consitst operation:value. Allowed operations eq, neq, in (comma list), prefix, suffix, ilike, notilike"
+// @param startTime query string false "Range sorting. Accept RFC3339 format time"
+// @param endTime query string false "Range sorting. Accept RFC3339 format time"
+// @param projectId query string false "Filter by project id (uuid)"
+// @param mode query string false "contained keeps tasks lying inside of the range entirely, overlap keeps tasks crossing it and clips their summary time by the range, contained by default" Enums(contained, overlap)
+// @param tz query string false "IANA timezone of xlsx days and timestamps, every user's timezone by default"
+// @param order query string false "Sort by summary time, desc by default" Enums(asc, desc)
+// @param format query string false "Response format, also negotiated by Accept header. xlsx is a timesheet with a worksheet per user" Enums(json, xlsx)
+// @param limit query uint64 false "Pagination control, xlsx covers all users without limit and offset"
+// @param offset query uint64 false "Pagination control"
+// @produce json,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @response 200 {object} []entities.UserSummary
+// @response 200 {file} file "Timesheets workbook"
+// @header 200 {string} Content-Disposition "attachment; filename=timesheets.xlsx, only for xlsx"
+// @response 204 "No any users by this request"
+// @response 400
+// @response 500
+// @router /reports/summary [get]
+func (r *reportRouter) Summary(c *gin.Context) {
+	query := summaryReqQuery{}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	representation := entities.UserSummaryRepresentation{
+		Pagination: entities.UserPagination{
+			Limit:  query.Limit,
+			Offset: query.Offset,
+		},
+		Filter: entities.UserFilter{
+			ByID:             query.ID,
+			BySurname:        query.Surname,
+			ByName:           query.Name,
+			ByPatronymic:     query.Patronymic,
+			ByAddress:        query.Address,
+			ByPassportNumber: query.PassportNumber,
+		},
+		Sort: entities.TaskSort{
+			StartTime: query.StartTime,
+			EndTime:   query.EndTime,
+			ProjectID: query.ProjectID,
+			Timezone:  query.Timezone,
+			Mode:      query.Mode,
+		},
+		Order: query.Order,
+	}
+
+	if query.Format == "xlsx" || query.Format == "" && c.NegotiateFormat(binding.MIMEJSON, MIMEXLSX) == MIMEXLSX {
+		r.timesheetsXLSX(c, representation)
+		return
+	}
+
+	users, err := r.taskUsecase.GetReportSummaryTimeByUsers(c.Request.Context(), representation)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+func (r *reportRouter) timesheetsXLSX(c *gin.Context, representation entities.UserSummaryRepresentation) {
+	timesheets, err := r.taskUsecase.GetReportTimesheets(c.Request.Context(), representation)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	f, err := renderTimesheets(timesheets)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+	defer f.Close()
+
+	setAttachmentHeader(c, "timesheets.xlsx")
+	c.Header("Content-Type", MIMEXLSX)
+	c.Status(http.StatusOK)
+
+	if err := f.Write(c.Writer); err != nil {
+		setAnyError(c, err)
+	}
+}
+
+type timesheetReqParams struct {
+	UserID string `uri:"userId" binding:"required,uuid"`
+}
+
+type timesheetReqQuery struct {
+	StartTime string `form:"startTime" binding:"omitempty,sorttime"`
+	EndTime   string `form:"endTime" binding:"omitempty,sorttime"`
+	Timezone  string `form:"tz" binding:"omitempty,timezone"`
+	Mode      string `form:"mode" binding:"omitempty,oneof=overlap contained"`
+}
+
+// @tags reports
+// @summary Get timesheet
+// @description Printable PDF timesheet for sign-off, tasks are grouped by day with totals and signature lines
+// @param userId path string true "User id (uuid)"
+// @param startTime query string false "Range sorting. Accept RFC3339 format time"
+// @param endTime query string false "Range sorting. Accept RFC3339 format time"
+// @param mode query string false "contained keeps tasks lying inside of the range entirely, overlap keeps tasks crossing it and clips their summary time by the range, contained by default" Enums(contained, overlap)
+// @param tz query string false "IANA timezone of days and timestamps, user's timezone by default"
+// @produce application/pdf
+// @response 200 {file} file "Timesheet"
+// @header 200 {string} Content-Disposition "attachment; filename=timesheet-{userId}.pdf"
+// @response 204 "There's no user with that id"
+// @response 400
+// @response 500
+// @router /reports/timesheet/{userId} [get]
+func (r *reportRouter) Timesheet(c *gin.Context) {
+	params := timesheetReqParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	query := timesheetReqQuery{}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	sort := entities.TaskSort{
+		StartTime: query.StartTime,
+		EndTime:   query.EndTime,
+		Timezone:  query.Timezone,
+		Mode:      query.Mode,
+	}
+
+	timesheet, err := r.taskUsecase.GetReportTimesheet(c.Request.Context(), params.UserID, sort)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	document, err := renderTimesheetPDF(timesheet, sort)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	setAttachmentHeader(c, fmt.Sprintf("timesheet-%s.pdf", params.UserID))
+	c.Data(http.StatusOK, MIMEPDF, document)
+}
@@ -0,0 +1,427 @@
+package v1_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestReportSummaryPositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type input struct {
+		surname   string
+		startTime string
+		order     string
+		limit     string
+	}
+	type user struct {
+		UserID      string `json:"userId"`
+		Surname     string `json:"surname"`
+		Name        string `json:"name"`
+		TasksCount  int    `json:"tasksCount"`
+		SummaryTime string `json:"summaryTime"`
+	}
+
+	testCases := []struct {
+		key      string
+		input    input
+		expected []user
+	}{
+		{
+			key: "top 3",
+			input: input{
+				limit: "3",
+			},
+			expected: []user{
+				{
+					UserID:      getUserID(postgres, 3),
+					Surname:     "Rippin",
+					Name:        "Katrine",
+					TasksCount:  5,
+					SummaryTime: "5234h28m",
+				},
+				{
+					UserID:      getUserID(postgres, 4),
+					Surname:     "Schulist",
+					Name:        "Kailee",
+					TasksCount:  2,
+					SummaryTime: "3045h43m",
+				},
+				{
+					UserID:      getUserID(postgres, 2),
+					Surname:     "McCullough",
+					Name:        "Jessie",
+					TasksCount:  4,
+					SummaryTime: "242h8m",
+				},
+			},
+		},
+		{
+			key: "with start time",
+			input: input{
+				surname:   "eq:McCullough",
+				startTime: "2024-05-01T00:00:00Z",
+			},
+			expected: []user{
+				{
+					UserID:      getUserID(postgres, 2),
+					Surname:     "McCullough",
+					Name:        "Jessie",
+					TasksCount:  2,
+					SummaryTime: "48h8m",
+				},
+			},
+		},
+		{
+			key: "asc order",
+			input: input{
+				surname: "ilike:i",
+				order:   "asc",
+			},
+			expected: []user{
+				{
+					UserID:  getUserID(postgres, 1),
+					Surname: "Runolfsdottir",
+					Name:    "Violette",
+				},
+				{
+					UserID:      getUserID(postgres, 4),
+					Surname:     "Schulist",
+					Name:        "Kailee",
+					TasksCount:  2,
+					SummaryTime: "3045h43m",
+				},
+				{
+					UserID:      getUserID(postgres, 3),
+					Surname:     "Rippin",
+					Name:        "Katrine",
+					TasksCount:  5,
+					SummaryTime: "5234h28m",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			query := url.Values{}
+
+			query.Set("surname", tc.input.surname)
+			query.Set("startTime", tc.input.startTime)
+			query.Set("order", tc.input.order)
+			query.Set("limit", tc.input.limit)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/reports/summary?%s", query.Encode()), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+
+			users := make([]user, 0)
+			json.NewDecoder(recorder.Body).Decode(&users)
+
+			assert.Equal(t, tc.expected, users, tc.key)
+		})
+	}
+}
+
+func TestReportSummaryXLSX(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type input struct {
+		surname string
+		format  string
+		accept  string
+	}
+
+	expected := map[string][][]string{
+		"1 McCullough Jessie": {
+			{"Date", "Task", "Title", "Project", "Started", "Finished", "Duration"},
+			{"2024-01-16", getTaskID(postgres, 7), "", getProjectID(postgres, 1), "2024-01-16 07:00", "2024-01-16 09:08", "2:08"},
+			{"2024-01-16", "", "Day total", "", "", "", "2:08"},
+			{"2024-03-16", getTaskID(postgres, 8), "", "", "2024-03-16 00:08", "2024-03-24 00:00", "191:52"},
+			{"2024-03-16", "", "Day total", "", "", "", "191:52"},
+			{"2024-05-18", getTaskID(postgres, 6), "", getProjectID(postgres, 0), "2024-05-18 11:00", "2024-05-20 09:08", "46:08"},
+			{"2024-05-18", "", "Day total", "", "", "", "46:08"},
+			{"2024-11-16", getTaskID(postgres, 5), "", getProjectID(postgres, 0), "2024-11-16 07:08", "2024-11-16 09:08", "2:00"},
+			{"2024-11-16", "", "Day total", "", "", "", "2:00"},
+			{"", "", "Total", "", "", "", "242:08"},
+		},
+	}
+
+	testCases := []struct {
+		key   string
+		input input
+	}{
+		{
+			key: "by format",
+			input: input{
+				surname: "eq:McCullough",
+				format:  "xlsx",
+			},
+		},
+		{
+			key: "by accept header",
+			input: input{
+				surname: "eq:McCullough",
+				accept:  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			query := url.Values{}
+
+			query.Set("surname", tc.input.surname)
+			query.Set("format", tc.input.format)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/reports/summary?%s", query.Encode()), nil)
+			req.Header.Set("Accept", tc.input.accept)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+			assert.Equal(t, `attachment; filename="timesheets.xlsx"`, recorder.Header().Get("Content-Disposition"), tc.key)
+
+			f, err := excelize.OpenReader(recorder.Body)
+			assert.NoError(t, err, tc.key)
+
+			sheets := make(map[string][][]string)
+
+			for _, sheet := range f.GetSheetList() {
+				sheets[sheet], _ = f.GetRows(sheet)
+			}
+
+			assert.Equal(t, expected, sheets, tc.key)
+		})
+	}
+}
+
+func TestReportSummaryXLSXAllUsers(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	builder := postgres.Builder.Insert("users").
+		Columns("surname", "name", "patronymic", "address", "passport_number")
+
+	for i := range 12 {
+		builder = builder.Values("Doe", fmt.Sprintf("John %d", i), "", "", fmt.Sprintf("7777 %06d", i))
+	}
+
+	sql, args, _ := builder.ToSql()
+	postgres.Pool.Exec(context.Background(), sql, args...)
+
+	req, _ := http.NewRequest("GET", "/v1/reports/summary?format=xlsx", nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	f, err := excelize.OpenReader(recorder.Body)
+	if assert.NoError(t, err) {
+		assert.Len(t, f.GetSheetList(), 17, "every user has a worksheet regardless of the default limit")
+	}
+}
+
+func TestReportSummaryNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type input struct {
+		surname   string
+		startTime string
+		order     string
+		limit     string
+	}
+
+	testCases := []struct {
+		key          string
+		input        input
+		expectedCode int
+	}{
+		{
+			key: "no any users",
+			input: input{
+				surname: "eq:Bode",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "with limit 0",
+			input: input{
+				limit: "0",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "unknown order",
+			input: input{
+				order: "up",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "not RFC3339 time",
+			input: input{
+				startTime: "2024-05-01",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "wrong filter operation",
+			input: input{
+				surname: "like:Funk",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			query := url.Values{}
+
+			query.Set("surname", tc.input.surname)
+			query.Set("startTime", tc.input.startTime)
+			query.Set("order", tc.input.order)
+			query.Set("limit", tc.input.limit)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/reports/summary?%s", query.Encode()), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestReportTimesheetPositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type input struct {
+		id        string
+		startTime string
+		endTime   string
+	}
+
+	testCases := []struct {
+		key   string
+		input input
+	}{
+		{
+			key: "case 1",
+			input: input{
+				id: getUserID(postgres, 2),
+			},
+		},
+		{
+			key: "with range",
+			input: input{
+				id:        getUserID(postgres, 3),
+				startTime: "2024-03-01T00:00:00Z",
+				endTime:   "2024-06-01T00:00:00Z",
+			},
+		},
+		{
+			key: "user without tasks",
+			input: input{
+				id: getUserID(postgres, 0),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			query := url.Values{}
+
+			query.Set("startTime", tc.input.startTime)
+			query.Set("endTime", tc.input.endTime)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/reports/timesheet/%s?%s", tc.input.id, query.Encode()), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+			assert.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"), tc.key)
+			assert.Equal(t, fmt.Sprintf(`attachment; filename="timesheet-%s.pdf"`, tc.input.id), recorder.Header().Get("Content-Disposition"), tc.key)
+			assert.True(t, strings.HasPrefix(recorder.Body.String(), "%PDF-"), tc.key)
+		})
+	}
+}
+
+func TestReportTimesheetNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type input struct {
+		id        string
+		startTime string
+	}
+
+	testCases := []struct {
+		key          string
+		input        input
+		expectedCode int
+	}{
+		{
+			key: "there's in no user with that id",
+			input: input{
+				id: "1ef44ce4-6afb-6da0-9e4e-6ea3cb7df39c",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "wrong id type",
+			input: input{
+				id: "2",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "not RFC3339 time",
+			input: input{
+				id:        getUserID(postgres, 2),
+				startTime: "2024-03-01",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			query := url.Values{}
+
+			query.Set("startTime", tc.input.startTime)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/reports/timesheet/%s?%s", tc.input.id, query.Encode()), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
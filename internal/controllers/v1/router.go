@@ -10,13 +10,15 @@ import (
 )
 
 type Config struct {
-	Mode string `koanf:"APP_GIN_MODE"`
+	Mode        string `koanf:"APP_GIN_MODE"`
+	AuthEnabled bool   `koanf:"APP_AUTH_ENABLED"`
 }
 
 type Router struct {
 	Handler  *gin.Engine
 	Usecases *usecases.Usecases
 	Log      logger.Logger
+	Config   Config
 }
 
 // @title time-tracker API
@@ -24,6 +26,10 @@ type Router struct {
 
 // @host localhost:8081
 // @BasePath /v1
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 func Handle(router *Router) {
 	router.Handler.Use(gin.Recovery())
 
@@ -33,13 +39,38 @@ func Handle(router *Router) {
 	v1 := router.Handler.Group("/v1")
 
 	v1.Use(trackingHandler(router.Log), errorHandler(router.Log))
+
+	protected := v1.Group("")
+	calendar := v1.Group("")
+
+	if router.Config.AuthEnabled {
+		protected.Use(authHandler(router.Usecases.Auth))
+		calendar.Use(calendarAuthHandler(router.Usecases.Auth))
+	}
+
 	{
-		handleUser(&userRouter{
+		handleAuth(&authRouter{
 			handler:     v1,
+			protected:   protected,
+			authUsecase: router.Usecases.Auth,
+			authEnabled: router.Config.AuthEnabled,
+		})
+		handleUser(&userRouter{
+			handler:     protected,
 			userUsecase: router.Usecases.User,
 		})
 		handleTask(&taskRouter{
-			handler:     v1,
+			handler:     protected,
+			calendar:    calendar,
+			taskUsecase: router.Usecases.Task,
+			log:         router.Log,
+		})
+		handleProject(&projectRouter{
+			handler:        protected,
+			projectUsecase: router.Usecases.Project,
+		})
+		handleReport(&reportRouter{
+			handler:     protected,
 			taskUsecase: router.Usecases.Task,
 		})
 	}
@@ -1,24 +1,45 @@
 package v1
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/v1adhope/time-tracker/internal/entities"
 	"github.com/v1adhope/time-tracker/internal/usecases"
+	"github.com/v1adhope/time-tracker/pkg/logger"
 )
 
 type taskRouter struct {
 	handler     *gin.RouterGroup
+	calendar    *gin.RouterGroup
 	taskUsecase usecases.Task
+	log         logger.Logger
 }
 
 func handleTask(router *taskRouter) {
 	tasks := router.handler.Group("/tasks")
 	{
+		tasks.POST("/", router.Create)
 		tasks.POST("/start/:userId", router.Start)
 		tasks.PATCH("/end/:id", router.End)
+		tasks.PATCH("/pause/:id", router.Pause)
+		tasks.PATCH("/resume/:id", router.Resume)
+		tasks.PATCH("/:id", router.Update)
+		tasks.DELETE("/:id", router.Delete)
+		tasks.GET("/:id", router.Get)
 		tasks.GET("/summary-time/:userId", router.SummaryTime)
+		tasks.GET("/summary-time/:userId/projects", router.SummaryTimeByProject)
+		tasks.GET("/overlaps/:userId", router.Overlaps)
+	}
+
+	calendar := router.calendar.Group("/tasks")
+	{
+		calendar.GET("/calendar/:file", router.Calendar)
 	}
 }
 
@@ -26,12 +47,21 @@ type startTaskReqParams struct {
 	UserID string `uri:"userId" binding:"required,uuid"`
 }
 
+type startTaskReq struct {
+	Title       string   `json:"title" binding:"required,max=255" example:"Code review"`
+	Description string   `json:"description" example:"Review pending merge requests"`
+	Labels      []string `json:"labels" binding:"omitempty,max=32,unique,dive,required,max=64" example:"review,backend"`
+	ProjectID   string   `json:"projectId" binding:"omitempty,uuid" example:"1ef5a3c1-2b4e-6f10-9c3d-5b7e1a2f4c6d"`
+}
+
 // @tags tasks
 // @summary Start task
 // @param userId path string true "User id (uuid)"
+// @accept json
+// @param task body startTaskReq true "Task request model"
 // @response 201
 // @header 201 {string} Location "Return /v1/tasks/summary-time/:userId resource"
-// @response 204 "There's no user with that id"
+// @response 204 "There's no user or project with that id"
 // @response 400
 // @response 500
 // @router /tasks/start/{userId} [post]
@@ -43,7 +73,20 @@ func (r *taskRouter) Start(c *gin.Context) {
 		return
 	}
 
-	if err := r.taskUsecase.Start(c.Request.Context(), params.UserID); err != nil {
+	req := startTaskReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	if err := r.taskUsecase.Start(c.Request.Context(), entities.Task{
+		UserID:      params.UserID,
+		Title:       req.Title,
+		Description: req.Description,
+		Labels:      req.Labels,
+		ProjectID:   req.ProjectID,
+	}); err != nil {
 		setAnyError(c, err)
 		return
 	}
@@ -53,6 +96,54 @@ func (r *taskRouter) Start(c *gin.Context) {
 	c.Status(http.StatusCreated)
 }
 
+type createTaskReq struct {
+	UserID      string   `json:"userId" binding:"required,uuid" example:"1ef4e803-1aed-62e0-8d59-c8cfd7561759"`
+	CreatedAt   string   `json:"createdAt" binding:"required,sorttime" example:"2024-01-16T09:08:25Z"`
+	FinishedAt  string   `json:"finishedAt" binding:"required,sorttime" example:"2024-01-16T11:08:25Z"`
+	Title       string   `json:"title" binding:"required,max=255" example:"Code review"`
+	Description string   `json:"description" example:"Review pending merge requests"`
+	Labels      []string `json:"labels" binding:"omitempty,max=32,unique,dive,required,max=64" example:"review,backend"`
+	ProjectID   string   `json:"projectId" binding:"omitempty,uuid" example:"1ef5a3c1-2b4e-6f10-9c3d-5b7e1a2f4c6d"`
+}
+
+// @tags tasks
+// @summary Create finished task manually
+// @description Backfill already done work. finishedAt should be after createdAt and not in the future
+// @accept json
+// @param task body createTaskReq true "Task request model. Accept RFC3339 format time"
+// @response 201
+// @header 201 {string} Location "Return /v1/tasks/summary-time/:userId resource"
+// @response 204 "There's no user or project with that id"
+// @response 400
+// @response 409 "Task overlaps another task of this user"
+// @response 500
+// @router /tasks [post]
+func (r *taskRouter) Create(c *gin.Context) {
+	req := createTaskReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	if _, err := r.taskUsecase.Create(c.Request.Context(), entities.Task{
+		UserID:      req.UserID,
+		CreatedAt:   req.CreatedAt,
+		FinishedAt:  req.FinishedAt,
+		Title:       req.Title,
+		Description: req.Description,
+		Labels:      req.Labels,
+		ProjectID:   req.ProjectID,
+	}); err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	setTaskLocationHeader(c, req.UserID)
+
+	c.Status(http.StatusCreated)
+}
+
 type endTaskReqParams struct {
 	ID string `uri:"id" binding:"required,uuid"`
 }
@@ -61,8 +152,9 @@ type endTaskReqParams struct {
 // @summary End task
 // @param id path string true "Task id (uuid)"
 // @response 200
-// @response 204 "There's no user with that id"
+// @response 204 "There's no task with that id"
 // @response 400
+// @response 409 "Task has already finished, original finishedAt is returned"
 // @response 500
 // @router /tasks/end/{id} [patch]
 func (r *taskRouter) End(c *gin.Context) {
@@ -74,6 +166,13 @@ func (r *taskRouter) End(c *gin.Context) {
 	}
 
 	finishedAt, err := r.taskUsecase.End(c.Request.Context(), params.ID)
+	if errors.Is(err, entities.ErrorTaskAlreadyFinished) {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"msg":        err.Error(),
+			"finishedAt": finishedAt,
+		})
+		return
+	}
 	if err != nil {
 		setAnyError(c, err)
 		return
@@ -84,6 +183,221 @@ func (r *taskRouter) End(c *gin.Context) {
 	})
 }
 
+type pauseTaskReqParams struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// @tags tasks
+// @summary Pause task
+// @description Closes the current active interval, time until resume isn't counted
+// @param id path string true "Task id (uuid)"
+// @response 200
+// @response 204 "There's no task with that id"
+// @response 400
+// @response 409 "Task has already paused or finished"
+// @response 500
+// @router /tasks/pause/{id} [patch]
+func (r *taskRouter) Pause(c *gin.Context) {
+	params := pauseTaskReqParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	pausedAt, err := r.taskUsecase.Pause(c.Request.Context(), params.ID)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pausedAt": pausedAt,
+	})
+}
+
+type resumeTaskReqParams struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// @tags tasks
+// @summary Resume task
+// @description Opens a new active interval of paused task
+// @param id path string true "Task id (uuid)"
+// @response 200
+// @response 204 "There's no task with that id"
+// @response 400
+// @response 409 "Task isn't paused or has already finished"
+// @response 500
+// @router /tasks/resume/{id} [patch]
+func (r *taskRouter) Resume(c *gin.Context) {
+	params := resumeTaskReqParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	resumedAt, err := r.taskUsecase.Resume(c.Request.Context(), params.ID)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"resumedAt": resumedAt,
+	})
+}
+
+type updateTaskReqParams struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+type updateTaskReq struct {
+	CreatedAt   string   `json:"createdAt" binding:"omitempty,sorttime" example:"2024-01-16T09:08:25Z"`
+	FinishedAt  string   `json:"finishedAt" binding:"omitempty,sorttime" example:"2024-01-16T11:08:25Z"`
+	Title       string   `json:"title" binding:"max=255" example:"Code review"`
+	Description string   `json:"description" example:"Review pending merge requests"`
+	Labels      []string `json:"labels" binding:"omitempty,max=32,unique,dive,required,max=64" example:"review,backend"`
+	ProjectID   string   `json:"projectId" binding:"omitempty,uuid" example:"1ef5a3c1-2b4e-6f10-9c3d-5b7e1a2f4c6d"`
+}
+
+// @tags tasks
+// @summary Update task
+// @description createdAt moves the start of the first active interval, finishedAt moves the end of the last one
+// @param id path string true "Task id (uuid)"
+// @accept json
+// @param task body updateTaskReq true "Task request model. Accept RFC3339 format time. Empty labels array clears labels"
+// @response 200
+// @response 204 "There's no task or project with that id"
+// @response 400 "Invalid or empty request model"
+// @response 409 "Task is running, finishedAt can't be changed or task overlaps another task of this user"
+// @response 500
+// @router /tasks/{id} [patch]
+func (r *taskRouter) Update(c *gin.Context) {
+	params := updateTaskReqParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	req := updateTaskReq{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	if err := r.taskUsecase.Update(c.Request.Context(), entities.Task{
+		ID:          params.ID,
+		CreatedAt:   req.CreatedAt,
+		FinishedAt:  req.FinishedAt,
+		Title:       req.Title,
+		Description: req.Description,
+		Labels:      req.Labels,
+		ProjectID:   req.ProjectID,
+	}); err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+type deleteTaskReqParams struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// @tags tasks
+// @summary Delete task
+// @param id path string true "Task id (uuid)"
+// @response 200
+// @response 204 "There's no task to delete"
+// @response 400
+// @response 500
+// @router /tasks/{id} [delete]
+func (r *taskRouter) Delete(c *gin.Context) {
+	params := deleteTaskReqParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	if err := r.taskUsecase.Delete(c.Request.Context(), params.ID); err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+type getTaskReqParams struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// @tags tasks
+// @summary Get task
+// @param id path string true "Task id (uuid)"
+// @response 200 {object} entities.Task
+// @response 204 "There's no task with that id"
+// @response 400
+// @response 500
+// @router /tasks/{id} [get]
+func (r *taskRouter) Get(c *gin.Context) {
+	params := getTaskReqParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	task, err := r.taskUsecase.Get(c.Request.Context(), params.ID)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+type overlapsReqParams struct {
+	UserID string `uri:"userId" binding:"required,uuid"`
+}
+
+// @tags tasks
+// @summary Get overlapping tasks
+// @description Audit of user's tasks which intervals intersect, every pair is returned once
+// @param userId path string true "User id (uuid)"
+// @response 200 {object} []entities.TaskOverlap
+// @response 204 "There're no overlapping tasks"
+// @response 400
+// @response 500
+// @router /tasks/overlaps/{userId} [get]
+func (r *taskRouter) Overlaps(c *gin.Context) {
+	params := overlapsReqParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	overlaps, err := r.taskUsecase.GetOverlaps(c.Request.Context(), params.UserID)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, overlaps)
+}
+
+const MIMECSV = "text/csv"
+
+var summaryTimeCSVHeader = []string{
+	"userId", "surname", "name", "taskId", "title", "description", "labels", "projectId", "createdAt", "finishedAt", "summaryTime",
+}
+
 type summaryTimeReqParams struct {
 	UserID string `uri:"userId" binding:"required,uuid"`
 }
@@ -91,14 +405,49 @@ type summaryTimeReqParams struct {
 type summaryTimeReqQuery struct {
 	StartTime string `form:"startTime" binding:"omitempty,sorttime"`
 	EndTime   string `form:"endTime" binding:"omitempty,sorttime"`
+	ProjectID string `form:"projectId" binding:"omitempty,uuid"`
+	Timezone  string `form:"tz" binding:"omitempty,timezone"`
+	Mode      string `form:"mode" binding:"omitempty,oneof=overlap contained"`
+	Sort      string `form:"sort" binding:"omitempty,summarysort"`
+	GroupBy   string `form:"groupBy" binding:"omitempty,oneof=day week month"`
+	Format    string `form:"format" binding:"omitempty,oneof=json csv"`
+	Delimiter string `form:"delimiter" binding:"omitempty,csvdelimiter"`
+
+	DurationFormat string `form:"durationFormat" binding:"omitempty,oneof=human seconds iso8601 all"`
+	Rounding       string `form:"rounding" binding:"omitempty,oneof=none minute quarter-hour"`
+
+	Limit  string `form:"limit" binding:"omitempty,number"`
+	Offset string `form:"offset" binding:"omitempty,number"`
+	Cursor string `form:"cursor" binding:"omitempty,cursor,excluded_with=Offset"`
 }
 
 // @tags tasks
 // @summary Get summary time
+// @description summaryTime is the sum of task's active intervals, pauses aren't counted.
+// @description With groupBy tasks are bucketed by the period of their start, weeks start on Monday
 // @param userId path string true "User id (uuid)"
 // @param startTime query string false "Range sorting. Accept RFC3339 format time"
 // @param endTime query string false "Range sorting. Accept RFC3339 format time"
-// @response 200 {object} []entities.Task
+// @param projectId query string false "Filter by project id (uuid)"
+// @param mode query string false "contained keeps tasks lying inside of the range entirely, overlap keeps tasks crossing it and clips their summary time by the range, contained by default" Enums(contained, overlap)
+// @param tz query string false "IANA timezone of returned timestamps and periods, user's timezone by default"
+// @param sort query string false "Order as field:direction, summaryTime:desc by default. Running tasks go last in ascending order and first in descending one. Ignored with groupBy" Enums(createdAt:asc, createdAt:desc, finishedAt:asc, finishedAt:desc, summaryTime:asc, summaryTime:desc, id:asc, id:desc)
+// @param groupBy query string false "Aggregate by period" Enums(day, week, month)
+// @param format query string false "Response format, also negotiated by Accept header. Ignored with groupBy" Enums(json, csv)
+// @param delimiter query string false "CSV field delimiter, comma by default"
+// @param durationFormat query string false "Adds summaryTimeSeconds and/or summaryTimeIso to summaryTime. Only human with csv" Enums(human, seconds, iso8601, all)
+// @param rounding query string false "Rounding of summary time, minute by default. Applies to csv rows and total as well" Enums(none, minute, quarter-hour)
+// @param limit query uint64 false "Pagination control, all tasks are returned without limit, offset and cursor. Ignored with groupBy and csv"
+// @param offset query uint64 false "Pagination control"
+// @param cursor query string false "Opaque cursor from X-Next-Cursor of the previous page. Pages go in order of task id, id:asc without sort. Can't be used with offset and other sort"
+// @produce json,text/csv
+// @response 200 {object} []entities.TaskSummary
+// @header 200 {integer} X-Total-Count "Count of all tasks of the report"
+// @header 200 {string} X-Next-Cursor "Cursor of the next page, only for a full page sorted by id"
+// @header 200 {string} Link "Link to the next page with rel=next, only along with X-Next-Cursor"
+// @response 200 {object} entities.TaskPeriodReport "With groupBy"
+// @response 200 {string} string "CSV with user columns and a trailing total row"
+// @header 200 {string} Content-Disposition "attachment; filename=summary-time-{userId}.csv, only for CSV"
 // @response 204
 // @response 400
 // @response 500
@@ -118,12 +467,266 @@ func (r *taskRouter) SummaryTime(c *gin.Context) {
 		return
 	}
 
-	tasks, err := r.taskUsecase.GetReportSummaryTime(
+	sort := entities.TaskSort{
+		StartTime: query.StartTime,
+		EndTime:   query.EndTime,
+		ProjectID: query.ProjectID,
+		Timezone:  query.Timezone,
+		Mode:      query.Mode,
+		Order:     parseTaskOrder(query.Sort),
+	}
+
+	if query.Cursor != "" {
+		if query.Sort == "" {
+			sort.Order.By = entities.TaskOrderByID
+		}
+
+		if sort.Order.By != entities.TaskOrderByID {
+			setAnyError(c, entities.ErrorTaskCursorWithoutID)
+			return
+		}
+	}
+
+	if query.GroupBy != "" {
+		report, err := r.taskUsecase.GetReportSummaryTimeByPeriod(c.Request.Context(), params.UserID, query.GroupBy, sort)
+		if err != nil {
+			setAnyError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	if query.Format == "csv" || query.Format == "" && c.NegotiateFormat(binding.MIMEJSON, MIMECSV) == MIMECSV {
+		if query.DurationFormat != "" && query.DurationFormat != entities.DurationFormatHuman {
+			setAnyError(c, entities.ErrorTaskDurationFormatCSV)
+			return
+		}
+
+		r.summaryTimeCSV(c, params.UserID, sort, query.Delimiter, query.Rounding)
+		return
+	}
+
+	pagination := entities.TaskPagination{
+		Limit:  query.Limit,
+		Offset: query.Offset,
+		Cursor: decodeCursor(query.Cursor),
+	}
+
+	page, err := r.taskUsecase.GetReportSummaryTime(c.Request.Context(), params.UserID, sort, pagination, entities.DurationRepresentation{
+		Format:   query.DurationFormat,
+		Rounding: query.Rounding,
+	})
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	setTotalCountHeader(c, page.Total)
+
+	if page.NextCursor != "" {
+		setNextPageHeaders(c, encodeCursor(page.NextCursor))
+	}
+
+	c.JSON(http.StatusOK, page.Tasks)
+}
+
+// summaryTimeCSV streams rows as RFC 4180 CSV, headers are written with the first row
+// so an empty report still ends up as 204. Once the first row is out the status is sent,
+// so a later failure is only logged and the file is cut.
+func (r *taskRouter) summaryTimeCSV(c *gin.Context, userID string, sort entities.TaskSort, delimiter, rounding string) {
+	w := csv.NewWriter(c.Writer)
+	w.UseCRLF = true
+
+	if delimiter != "" {
+		w.Comma = []rune(delimiter)[0]
+	}
+
+	last := entities.TaskSummaryRecord{}
+
+	err := r.taskUsecase.ExportReportSummaryTime(c.Request.Context(), userID, sort, entities.DurationRepresentation{
+		Rounding: rounding,
+	}, func(record entities.TaskSummaryRecord) error {
+		if last.User.ID == "" {
+			setAttachmentHeader(c, fmt.Sprintf("summary-time-%s.csv", userID))
+			c.Header("Content-Type", MIMECSV)
+			c.Status(http.StatusOK)
+
+			if err := w.Write(summaryTimeCSVHeader); err != nil {
+				return err
+			}
+		}
+
+		last = record
+
+		return w.Write([]string{
+			record.User.ID,
+			record.User.Surname,
+			record.User.Name,
+			record.Task.ID,
+			record.Task.Title,
+			record.Task.Description,
+			strings.Join(record.Task.Labels, ","),
+			record.Task.ProjectID,
+			record.Task.CreatedAt,
+			record.Task.FinishedAt,
+			record.Task.SummaryTime,
+		})
+	})
+	if err != nil {
+		if last.User.ID == "" {
+			setAnyError(c, err)
+			return
+		}
+
+		r.log.Error(fmt.Errorf("v1: summaryTimeCSV: export: %w", err))
+		return
+	}
+
+	if err := w.Write([]string{
+		last.User.ID,
+		last.User.Surname,
+		last.User.Name,
+		"",
+		fmt.Sprintf("Total: %d tasks", last.TotalTasksCount),
+		"", "", "", "", "",
+		last.TotalSummaryTime,
+	}); err != nil {
+		r.log.Error(fmt.Errorf("v1: summaryTimeCSV: write total: %w", err))
+		return
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		r.log.Error(fmt.Errorf("v1: summaryTimeCSV: flush: %w", err))
+	}
+}
+
+// parseTaskOrder expects validated field:direction string, empty one gives default order.
+func parseTaskOrder(sort string) entities.TaskOrder {
+	by, direction, _ := strings.Cut(sort, ":")
+
+	return entities.TaskOrder{
+		By:   by,
+		Desc: direction == "desc",
+	}
+}
+
+type calendarReqParams struct {
+	File string `uri:"file" binding:"required,endswith=.ics"`
+}
+
+type calendarReqUser struct {
+	UserID string `binding:"required,uuid"`
+}
+
+type calendarReqQuery struct {
+	StartTime string `form:"startTime" binding:"omitempty,sorttime"`
+	EndTime   string `form:"endTime" binding:"omitempty,sorttime"`
+}
+
+// @tags tasks
+// @summary Get calendar of tasks
+// @description iCalendar feed, every finished task is a VEVENT with task id as UID. Running tasks are omitted.
+// @description With auth enabled it's authorized by token query parameter, see /auth/calendar-token
+// @param userId path string true "User id (uuid) with .ics extension"
+// @param token query string false "Calendar token of the user, required when auth is enabled"
+// @param startTime query string false "Range sorting. Accept RFC3339 format time"
+// @param endTime query string false "Range sorting. Accept RFC3339 format time"
+// @produce text/calendar
+// @response 200 {string} string "iCalendar"
+// @response 400
+// @response 401
+// @response 403
+// @response 500
+// @router /tasks/calendar/{userId}.ics [get]
+func (r *taskRouter) Calendar(c *gin.Context) {
+	params := calendarReqParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	user := calendarReqUser{
+		UserID: strings.TrimSuffix(params.File, ".ics"),
+	}
+
+	if err := binding.Validator.ValidateStruct(&user); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	// The calendar token is signed for the feed of its owner only
+	if ownerID, ok := usecases.UserIDFromContext(c.Request.Context()); ok && ownerID != user.UserID {
+		setAnyError(c, entities.ErrorForbidden)
+		return
+	}
+
+	query := calendarReqQuery{}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	page, err := r.taskUsecase.GetReportSummaryTime(c.Request.Context(), user.UserID, entities.TaskSort{
+		StartTime: query.StartTime,
+		EndTime:   query.EndTime,
+	}, entities.TaskPagination{}, entities.DurationRepresentation{})
+	// Calendar clients treat anything but a calendar as a broken feed, no tasks is just no events
+	if err != nil && !errors.Is(err, entities.ErrorNoAnyTasksForThisUser) {
+		setAnyError(c, err)
+		return
+	}
+
+	calendar, err := renderCalendar(page.Tasks)
+	if err != nil {
+		setAnyError(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, MIMECalendar, calendar)
+}
+
+// @tags tasks
+// @summary Get summary time grouped by project
+// @description Tasks without project are grouped under empty projectId
+// @param userId path string true "User id (uuid)"
+// @param startTime query string false "Range sorting. Accept RFC3339 format time"
+// @param endTime query string false "Range sorting. Accept RFC3339 format time"
+// @param projectId query string false "Filter by project id (uuid)"
+// @param mode query string false "contained keeps tasks lying inside of the range entirely, overlap keeps tasks crossing it and clips their summary time by the range, contained by default" Enums(contained, overlap)
+// @response 200 {object} []entities.ProjectSummary
+// @response 204
+// @response 400
+// @response 500
+// @router /tasks/summary-time/{userId}/projects [get]
+func (r *taskRouter) SummaryTimeByProject(c *gin.Context) {
+	params := summaryTimeReqParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	query := summaryTimeReqQuery{}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		setBindError(c, err)
+		return
+	}
+
+	projects, err := r.taskUsecase.GetReportSummaryTimeByProject(
 		c.Request.Context(),
 		params.UserID,
 		entities.TaskSort{
 			StartTime: query.StartTime,
 			EndTime:   query.EndTime,
+			ProjectID: query.ProjectID,
+			Mode:      query.Mode,
 		},
 	)
 	if err != nil {
@@ -131,5 +734,5 @@ func (r *taskRouter) SummaryTime(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, tasks)
+	c.JSON(http.StatusOK, projects)
 }
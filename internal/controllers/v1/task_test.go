@@ -1,156 +1,2367 @@
 package v1_test
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/stretchr/testify/assert"
+	"github.com/v1adhope/time-tracker/internal/configs"
+	"github.com/v1adhope/time-tracker/internal/usecases"
 )
 
+func TestTaskCreatePositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type task struct {
+		UserID     string   `json:"userId"`
+		CreatedAt  string   `json:"createdAt"`
+		FinishedAt string   `json:"finishedAt"`
+		Title      string   `json:"title"`
+		Labels     []string `json:"labels,omitempty"`
+		ProjectID  string   `json:"projectId,omitempty"`
+	}
+
+	testCases := []struct {
+		key  string
+		task task
+	}{
+		{
+			key: "case 1",
+			task: task{
+				UserID:     getUserID(postgres, 0),
+				CreatedAt:  "2024-02-01T09:00:00Z",
+				FinishedAt: "2024-02-01T11:00:00Z",
+				Title:      "Code review",
+			},
+		},
+		{
+			key: "with offset",
+			task: task{
+				UserID:     getUserID(postgres, 1),
+				CreatedAt:  "2024-02-01T09:00:00+03:00",
+				FinishedAt: "2024-02-01T10:30:00+03:00",
+				Title:      "Planning",
+				Labels:     []string{"meeting"},
+				ProjectID:  getProjectID(postgres, 1),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			bytesBody, _ := json.Marshal(&tc.task)
+			req, _ := http.NewRequest("POST", "/v1/tasks/", strings.NewReader(string(bytesBody)))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusCreated, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskCreateNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type task struct {
+		UserID     string `json:"userId"`
+		CreatedAt  string `json:"createdAt"`
+		FinishedAt string `json:"finishedAt"`
+		Title      string `json:"title"`
+	}
+
+	testCases := []struct {
+		key          string
+		task         task
+		expectedCode int
+	}{
+		{
+			key: "there's no user with that id",
+			task: task{
+				UserID:     "1ef44bc9-5c77-6c90-a41a-1f1b0b522ea3",
+				CreatedAt:  "2024-02-01T09:00:00Z",
+				FinishedAt: "2024-02-01T11:00:00Z",
+				Title:      "Code review",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "finished before created",
+			task: task{
+				UserID:     getUserID(postgres, 0),
+				CreatedAt:  "2024-02-01T11:00:00Z",
+				FinishedAt: "2024-02-01T09:00:00Z",
+				Title:      "Code review",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "finished in the future",
+			task: task{
+				UserID:     getUserID(postgres, 0),
+				CreatedAt:  "2024-02-01T11:00:00Z",
+				FinishedAt: "2999-02-01T09:00:00Z",
+				Title:      "Code review",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "not RFC3339 time",
+			task: task{
+				UserID:     getUserID(postgres, 0),
+				CreatedAt:  "2024-02-01 11:00:00",
+				FinishedAt: "2024-02-01T12:00:00Z",
+				Title:      "Code review",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "forgot finished time",
+			task: task{
+				UserID:    getUserID(postgres, 0),
+				CreatedAt: "2024-02-01T11:00:00Z",
+				Title:     "Code review",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "overlaps another task",
+			task: task{
+				UserID:     getUserID(postgres, 3),
+				CreatedAt:  "2024-01-16T10:00:00Z",
+				FinishedAt: "2024-01-16T17:00:00Z",
+				Title:      "Code review",
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			bytesBody, _ := json.Marshal(&tc.task)
+			req, _ := http.NewRequest("POST", "/v1/tasks/", strings.NewReader(string(bytesBody)))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskCreateConcurrentOverlap(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	bytesBody, _ := json.Marshal(map[string]string{
+		"userId":     getUserID(postgres, 0),
+		"createdAt":  "2024-02-01T09:00:00Z",
+		"finishedAt": "2024-02-01T11:00:00Z",
+		"title":      "Code review",
+	})
+
+	const requests = 8
+
+	codes := make(chan int, requests)
+
+	var wg sync.WaitGroup
+
+	for range requests {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			req, _ := http.NewRequest("POST", "/v1/tasks/", strings.NewReader(string(bytesBody)))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			codes <- recorder.Code
+		}()
+	}
+
+	wg.Wait()
+	close(codes)
+
+	created := 0
+
+	for code := range codes {
+		if code == http.StatusCreated {
+			created++
+			continue
+		}
+
+		assert.Equal(t, http.StatusConflict, code)
+	}
+
+	assert.Equal(t, 1, created)
+}
+
 func TestTaskStartPositive(t *testing.T) {
 	postgres, handler := prepare()
 	t.Cleanup(func() {
 		defer postgres.Close()
 	})
 
+	type task struct {
+		Title       string   `json:"title"`
+		Description string   `json:"description,omitempty"`
+		Labels      []string `json:"labels,omitempty"`
+		ProjectID   string   `json:"projectId,omitempty"`
+	}
+
+	testCases := []struct {
+		key    string
+		userID string
+		task   task
+	}{
+		{
+			key:    "case 1",
+			userID: getUserID(postgres, 0),
+			task: task{
+				Title: "Code review",
+			},
+		},
+		{
+			key:    "case 2",
+			userID: getUserID(postgres, 1),
+			task: task{
+				Title:       "Planning",
+				Description: "Sprint planning meeting",
+			},
+		},
+		{
+			key:    "case 3",
+			userID: getUserID(postgres, 2),
+			task: task{
+				Title:       "Deploy",
+				Description: "Release 1.2.0",
+				Labels:      []string{"ops", "release"},
+				ProjectID:   getProjectID(postgres, 0),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			bytesBody, _ := json.Marshal(&tc.task)
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/v1/tasks/start/%s", tc.userID), strings.NewReader(string(bytesBody)))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusCreated, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskStartNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		defer postgres.Close()
+	})
+
+	type task struct {
+		Title       string   `json:"title"`
+		Description string   `json:"description,omitempty"`
+		Labels      []string `json:"labels,omitempty"`
+		ProjectID   string   `json:"projectId,omitempty"`
+	}
+
+	testCases := []struct {
+		key          string
+		userID       string
+		task         task
+		expectedCode int
+	}{
+		{
+			key:    "there's no user with that id",
+			userID: "1ef44bc9-5c77-6c90-a41a-1f1b0b522ea3",
+			task: task{
+				Title: "Code review",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key:    "not correct type of id case 1",
+			userID: "5a55-6710-a9cf-ddslfjkjjj2e",
+			task: task{
+				Title: "Code review",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key:    "not correct type of id case 2",
+			userID: "1",
+			task: task{
+				Title: "Code review",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key:          "forgot title",
+			userID:       getUserID(postgres, 0),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key:    "duplicated labels",
+			userID: getUserID(postgres, 0),
+			task: task{
+				Title:  "Code review",
+				Labels: []string{"review", "review"},
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key:    "there's no project with that id",
+			userID: getUserID(postgres, 0),
+			task: task{
+				Title:     "Code review",
+				ProjectID: "1ef44bc9-5c77-6c90-a41a-1f1b0b522ea3",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key:    "not correct type of project id",
+			userID: getUserID(postgres, 0),
+			task: task{
+				Title:     "Code review",
+				ProjectID: "1",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key:    "user has already running task",
+			userID: getUserID(postgres, 3),
+			task: task{
+				Title: "Code review",
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			bytesBody, _ := json.Marshal(&tc.task)
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/v1/tasks/start/%s", tc.userID), strings.NewReader(string(bytesBody)))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskStartStopRunningPolicy(t *testing.T) {
+	postgres, handler := prepareWith(func(cfg *configs.Config) {
+		cfg.Usecases.TaskRunningPolicy = usecases.TaskRunningPolicyStop
+	})
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type task struct {
+		Title string `json:"title"`
+	}
+
+	testCases := []struct {
+		key    string
+		userID string
+		task   task
+	}{
+		{
+			key:    "stop seeded running task",
+			userID: getUserID(postgres, 3),
+			task: task{
+				Title: "Code review",
+			},
+		},
+		{
+			key:    "stop just started task",
+			userID: getUserID(postgres, 3),
+			task: task{
+				Title: "Planning",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			bytesBody, _ := json.Marshal(&tc.task)
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/v1/tasks/start/%s", tc.userID), strings.NewReader(string(bytesBody)))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusCreated, recorder.Code, tc.key)
+		})
+	}
+
+	var running int
+	sql, args, _ := postgres.Builder.Select("count(*)").
+		From("tasks").
+		Where(squirrel.Eq{"user_id": getUserID(postgres, 3), "finished_at": nil}).
+		ToSql()
+	postgres.Pool.QueryRow(context.Background(), sql, args...).Scan(&running)
+
+	assert.Equal(t, 1, running)
+}
+
+func TestTaskEndPositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		defer postgres.Close()
+	})
+
 	testCases := []struct {
 		key    string
 		userID string
 	}{
 		{
-			key:    "case 1",
-			userID: getUserID(postgres, 0),
+			key:    "case 1",
+			userID: getTaskID(postgres, 3),
+		},
+		{
+			key:    "case 2",
+			userID: getTaskID(postgres, 9),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("PATCH", fmt.Sprintf("/v1/tasks/end/%s", tc.userID), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskEndNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		defer postgres.Close()
+	})
+
+	testCases := []struct {
+		key          string
+		userID       string
+		expectedCode int
+	}{
+		{
+			key:          "there's no user with that id",
+			userID:       "1ef44bc9-5c77-6c90-a41a-1f1b0b522ea3",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key:          "case 1 not correct type of id",
+			userID:       "5a55-6710-a9cf-ddslfjkjjj2e",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key:          "case 2 not correct type of id",
+			userID:       "1",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("PATCH", fmt.Sprintf("/v1/tasks/end/%s", tc.userID), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskEndAlreadyFinished(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type response struct {
+		FinishedAt string `json:"finishedAt"`
+	}
+
+	testCases := []struct {
+		key      string
+		id       string
+		expected response
+	}{
+		{
+			key: "seeded finished task",
+			id:  getTaskID(postgres, 0),
+			expected: response{
+				FinishedAt: "2024-01-16T16:10:00Z",
+			},
+		},
+		{
+			key: "another seeded finished task",
+			id:  getTaskID(postgres, 8),
+			expected: response{
+				FinishedAt: "2024-03-24T00:00:00Z",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("PATCH", fmt.Sprintf("/v1/tasks/end/%s", tc.id), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusConflict, recorder.Code, tc.key)
+
+			response := response{}
+			json.NewDecoder(recorder.Body).Decode(&response)
+			assert.Equal(t, tc.expected, response, tc.key)
+		})
+	}
+}
+
+func TestTaskPausePositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	testCases := []struct {
+		key string
+		id  string
+	}{
+		{
+			key: "case 1",
+			id:  getTaskID(postgres, 3),
+		},
+		{
+			key: "case 2",
+			id:  getTaskID(postgres, 9),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("PATCH", fmt.Sprintf("/v1/tasks/pause/%s", tc.id), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskPauseNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	testCases := []struct {
+		key          string
+		id           string
+		expectedCode int
+	}{
+		{
+			key:          "there's no task with that id",
+			id:           "1ef44bc9-5c77-6c90-a41a-1f1b0b522ea3",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key:          "not correct type of id",
+			id:           "1",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key:          "task has already finished",
+			id:           getTaskID(postgres, 0),
+			expectedCode: http.StatusConflict,
+		},
+		{
+			key:          "first pause",
+			id:           getTaskID(postgres, 3),
+			expectedCode: http.StatusOK,
+		},
+		{
+			key:          "task has already paused",
+			id:           getTaskID(postgres, 3),
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("PATCH", fmt.Sprintf("/v1/tasks/pause/%s", tc.id), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskResumePositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	testCases := []struct {
+		key string
+		id  string
+	}{
+		{
+			key: "case 1",
+			id:  getTaskID(postgres, 3),
+		},
+		{
+			key: "case 2",
+			id:  getTaskID(postgres, 9),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("PATCH", fmt.Sprintf("/v1/tasks/pause/%s", tc.id), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+
+			req, _ = http.NewRequest("PATCH", fmt.Sprintf("/v1/tasks/resume/%s", tc.id), nil)
+			recorder = httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskResumeNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	testCases := []struct {
+		key          string
+		id           string
+		expectedCode int
+	}{
+		{
+			key:          "there's no task with that id",
+			id:           "1ef44bc9-5c77-6c90-a41a-1f1b0b522ea3",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key:          "not correct type of id",
+			id:           "1",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key:          "task has already finished",
+			id:           getTaskID(postgres, 0),
+			expectedCode: http.StatusConflict,
+		},
+		{
+			key:          "task isn't paused",
+			id:           getTaskID(postgres, 9),
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("PATCH", fmt.Sprintf("/v1/tasks/resume/%s", tc.id), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskUpdatePositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type task struct {
+		ID          string   `json:"-"`
+		CreatedAt   string   `json:"createdAt,omitempty"`
+		FinishedAt  string   `json:"finishedAt,omitempty"`
+		Title       string   `json:"title,omitempty"`
+		Description string   `json:"description,omitempty"`
+		Labels      []string `json:"labels,omitempty"`
+	}
+
+	testCases := []task{
+		{
+			ID:    getTaskID(postgres, 0),
+			Title: "Code review",
+		},
+		{
+			ID:          getTaskID(postgres, 1),
+			Description: "Sprint planning meeting",
+			Labels:      []string{"meeting"},
+		},
+		{
+			ID:          getTaskID(postgres, 2),
+			Title:       "Deploy",
+			Description: "Release 1.2.0",
+			Labels:      []string{"ops", "release"},
+		},
+		{
+			ID:         getTaskID(postgres, 5),
+			CreatedAt:  "2024-11-16T06:00:00Z",
+			FinishedAt: "2024-11-16T10:00:00Z",
+		},
+		{
+			ID:        getTaskID(postgres, 3),
+			CreatedAt: "2024-12-16T13:00:00+03:00",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.ID, func(t *testing.T) {
+			taskJSON, _ := json.Marshal(&tc)
+			req, _ := http.NewRequest("PATCH", fmt.Sprintf("/v1/tasks/%s", tc.ID), strings.NewReader(string(taskJSON)))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.ID)
+		})
+	}
+}
+
+func TestTaskUpdateNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type task struct {
+		CreatedAt   string   `json:"createdAt,omitempty"`
+		FinishedAt  string   `json:"finishedAt,omitempty"`
+		Title       string   `json:"title,omitempty"`
+		Description string   `json:"description,omitempty"`
+		Labels      []string `json:"labels,omitempty"`
+	}
+
+	testCases := []struct {
+		key          string
+		id           string
+		task         task
+		expectedCode int
+	}{
+		{
+			key: "there's no task with that id",
+			id:  "1ef44bc9-5c77-6c90-a41a-1f1b0b522ea3",
+			task: task{
+				Title: "Code review",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "not correct type of id",
+			id:  "1",
+			task: task{
+				Title: "Code review",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key:          "nothing to update",
+			id:           getTaskID(postgres, 0),
+			task:         task{},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "too long label",
+			id:  getTaskID(postgres, 0),
+			task: task{
+				Labels: []string{strings.Repeat("a", 65)},
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "finished before created",
+			id:  getTaskID(postgres, 0),
+			task: task{
+				FinishedAt: "2024-01-16T08:00:00Z",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "created after finished",
+			id:  getTaskID(postgres, 0),
+			task: task{
+				CreatedAt: "2024-01-17T08:00:00Z",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "finished in the future",
+			id:  getTaskID(postgres, 0),
+			task: task{
+				FinishedAt: "2999-01-16T08:00:00Z",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "not RFC3339 time",
+			id:  getTaskID(postgres, 0),
+			task: task{
+				CreatedAt: "2024-01-16 08:00:00",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "finish running task",
+			id:  getTaskID(postgres, 3),
+			task: task{
+				FinishedAt: "2024-12-16T10:00:00Z",
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			key: "overlaps another task",
+			id:  getTaskID(postgres, 0),
+			task: task{
+				FinishedAt: "2024-03-12T08:00:00Z",
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			taskJSON, _ := json.Marshal(&tc.task)
+			req, _ := http.NewRequest("PATCH", fmt.Sprintf("/v1/tasks/%s", tc.id), strings.NewReader(string(taskJSON)))
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskDeletePositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	testCases := []string{
+		getTaskID(postgres, 0),
+		getTaskID(postgres, 3),
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc, func(t *testing.T) {
+			req, _ := http.NewRequest("DELETE", fmt.Sprintf("/v1/tasks/%s", tc), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc)
+		})
+	}
+}
+
+func TestTaskDeleteNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	testCases := []struct {
+		key          string
+		id           string
+		expectedCode int
+	}{
+		{
+			key:          "there's no task with that id",
+			id:           "1ef44bc9-5c77-6c90-a41a-1f1b0b522ea3",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key:          "not correct type of id",
+			id:           "5a55-6710-a9cf-ddslfjkjjj2e",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("DELETE", fmt.Sprintf("/v1/tasks/%s", tc.id), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskGetPositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type task struct {
+		CreatedAt  string   `json:"createdAt"`
+		FinishedAt string   `json:"finishedAt"`
+		UserID     string   `json:"userId"`
+		Labels     []string `json:"labels"`
+		ProjectID  string   `json:"projectId"`
+	}
+
+	testCases := []struct {
+		key      string
+		id       string
+		expected task
+	}{
+		{
+			key: "finished task",
+			id:  getTaskID(postgres, 0),
+			expected: task{
+				CreatedAt:  "2024-01-16T09:08:25Z",
+				FinishedAt: "2024-01-16T16:10:00Z",
+				UserID:     getUserID(postgres, 3),
+				Labels:     []string{},
+			},
+		},
+		{
+			key: "running task",
+			id:  getTaskID(postgres, 3),
+			expected: task{
+				CreatedAt: "2024-12-16T09:08:25Z",
+				UserID:    getUserID(postgres, 3),
+				Labels:    []string{},
+			},
+		},
+		{
+			key: "task with project",
+			id:  getTaskID(postgres, 5),
+			expected: task{
+				CreatedAt:  "2024-11-16T07:08:25Z",
+				FinishedAt: "2024-11-16T09:08:25Z",
+				UserID:     getUserID(postgres, 2),
+				Labels:     []string{},
+				ProjectID:  getProjectID(postgres, 0),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/%s", tc.id), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+
+			task := task{}
+			json.NewDecoder(recorder.Body).Decode(&task)
+			assert.Equal(t, tc.expected, task, tc.key)
+		})
+	}
+}
+
+func TestTaskGetNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	testCases := []struct {
+		key          string
+		id           string
+		expectedCode int
+	}{
+		{
+			key:          "there's no task with that id",
+			id:           "1ef44bc9-5c77-6c90-a41a-1f1b0b522ea3",
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key:          "not correct type of id",
+			id:           "2",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/%s", tc.id), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskOverlapsPositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type overlap struct {
+		TaskID        string `json:"taskId"`
+		OverlapTaskID string `json:"overlapTaskId"`
+		StartedAt     string `json:"startedAt"`
+		FinishedAt    string `json:"finishedAt"`
+		OverlapTime   string `json:"overlapTime"`
+	}
+
+	testCases := []struct {
+		key      string
+		userID   string
+		expected []overlap
+	}{
+		{
+			key:    "case 1",
+			userID: getUserID(postgres, 3),
+			expected: []overlap{
+				{
+					TaskID:        getTaskID(postgres, 1),
+					OverlapTaskID: getTaskID(postgres, 2),
+					StartedAt:     "2024-04-16T09:08:25Z",
+					FinishedAt:    "2024-05-11T09:08:25Z",
+					OverlapTime:   "600h0m",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/overlaps/%s", tc.userID), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+
+			overlaps := make([]overlap, 0)
+			json.NewDecoder(recorder.Body).Decode(&overlaps)
+			assert.Equal(t, tc.expected, overlaps, tc.key)
+		})
+	}
+}
+
+func TestTaskOverlapsNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	testCases := []struct {
+		key          string
+		userID       string
+		expectedCode int
+	}{
+		{
+			key:          "there're no overlapping tasks",
+			userID:       getUserID(postgres, 2),
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key:          "not correct type of id",
+			userID:       "3",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/overlaps/%s", tc.userID), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskSummaryTimePositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		defer postgres.Close()
+	})
+
+	type input struct {
+		id        string
+		startTime string
+		endTime   string
+		projectID string
+		sort      string
+		mode      string
+	}
+	type task struct {
+		CreatedAt   string `json:"createdAt"`
+		FinishedAt  string `json:"finishedAt"`
+		SummaryTime string `json:"summaryTime"`
+	}
+
+	testCases := []struct {
+		key      string
+		input    input
+		expected []task
+	}{
+		{
+			key: "tasks' 4th user",
+			input: input{
+				id: getUserID(postgres, 3),
+			},
+			expected: []task{
+				{
+					CreatedAt: "2024-12-16T09:08:25Z",
+				},
+				{
+					CreatedAt:   "2024-08-11T11:25:00Z",
+					FinishedAt:  "2024-12-16T09:08:25Z",
+					SummaryTime: "3045h43m",
+				},
+				{
+					CreatedAt:   "2024-03-11T11:25:00Z",
+					FinishedAt:  "2024-05-11T09:08:25Z",
+					SummaryTime: "1461h43m",
+				},
+				{
+					CreatedAt:   "2024-04-16T09:08:25Z",
+					FinishedAt:  "2024-05-16T09:08:25Z",
+					SummaryTime: "720h0m",
+				},
+				{
+					CreatedAt:   "2024-01-16T09:08:25Z",
+					FinishedAt:  "2024-01-16T16:10:00Z",
+					SummaryTime: "7h2m",
+				},
+			},
+		},
+		{
+			key: "tasks' 3rd user",
+			input: input{
+				id: getUserID(postgres, 2),
+			},
+			expected: []task{
+				{
+					CreatedAt:   "2024-03-16T00:08:25Z",
+					FinishedAt:  "2024-03-24T00:00:00Z",
+					SummaryTime: "191h52m",
+				},
+				{
+					CreatedAt:   "2024-05-18T11:00:00Z",
+					FinishedAt:  "2024-05-20T09:08:25Z",
+					SummaryTime: "46h8m",
+				},
+				{
+					CreatedAt:   "2024-01-16T07:00:25Z",
+					FinishedAt:  "2024-01-16T09:08:25Z",
+					SummaryTime: "2h8m",
+				},
+				{
+					CreatedAt:   "2024-11-16T07:08:25Z",
+					FinishedAt:  "2024-11-16T09:08:25Z",
+					SummaryTime: "2h0m",
+				},
+			},
+		},
+		{
+			key: "tasks' 5 user",
+			input: input{
+				id: getUserID(postgres, 4),
+			},
+			expected: []task{
+				{
+					CreatedAt: "2024-12-16T09:08:25Z",
+				},
+				{
+					CreatedAt:   "2024-08-11T11:25:00Z",
+					FinishedAt:  "2024-12-16T09:08:25Z",
+					SummaryTime: "3045h43m",
+				},
+			},
+		},
+		{
+			key: "with start time",
+			input: input{
+				id:        getUserID(postgres, 3),
+				startTime: "2024-04-01T00:00:00Z",
+			},
+			expected: []task{
+				{
+					CreatedAt: "2024-12-16T09:08:25Z",
+				},
+				{
+					CreatedAt:   "2024-08-11T11:25:00Z",
+					FinishedAt:  "2024-12-16T09:08:25Z",
+					SummaryTime: "3045h43m",
+				},
+				{
+					CreatedAt:   "2024-04-16T09:08:25Z",
+					FinishedAt:  "2024-05-16T09:08:25Z",
+					SummaryTime: "720h0m",
+				},
+			},
+		},
+		{
+			key: "with end time",
+			input: input{
+				id:      getUserID(postgres, 3),
+				endTime: "2024-05-12T00:00:00Z",
+			},
+			expected: []task{
+				{
+					CreatedAt:   "2024-03-11T11:25:00Z",
+					FinishedAt:  "2024-05-11T09:08:25Z",
+					SummaryTime: "1461h43m",
+				},
+				{
+					CreatedAt:   "2024-01-16T09:08:25Z",
+					FinishedAt:  "2024-01-16T16:10:00Z",
+					SummaryTime: "7h2m",
+				},
+			},
+		},
+		{
+			key: "with start and end time",
+			input: input{
+				id:        getUserID(postgres, 3),
+				startTime: "2024-02-01T00:00:00Z",
+				endTime:   "2024-05-12T00:00:00Z",
+			},
+			expected: []task{
+				{
+					CreatedAt:   "2024-03-11T11:25:00Z",
+					FinishedAt:  "2024-05-11T09:08:25Z",
+					SummaryTime: "1461h43m",
+				},
+			},
+		},
+		{
+			key: "with project",
+			input: input{
+				id:        getUserID(postgres, 2),
+				projectID: getProjectID(postgres, 0),
+			},
+			expected: []task{
+				{
+					CreatedAt:   "2024-05-18T11:00:00Z",
+					FinishedAt:  "2024-05-20T09:08:25Z",
+					SummaryTime: "46h8m",
+				},
+				{
+					CreatedAt:   "2024-11-16T07:08:25Z",
+					FinishedAt:  "2024-11-16T09:08:25Z",
+					SummaryTime: "2h0m",
+				},
+			},
+		},
+		{
+			key: "by created at ascending",
+			input: input{
+				id:   getUserID(postgres, 3),
+				sort: "createdAt:asc",
+			},
+			expected: []task{
+				{
+					CreatedAt:   "2024-01-16T09:08:25Z",
+					FinishedAt:  "2024-01-16T16:10:00Z",
+					SummaryTime: "7h2m",
+				},
+				{
+					CreatedAt:   "2024-03-11T11:25:00Z",
+					FinishedAt:  "2024-05-11T09:08:25Z",
+					SummaryTime: "1461h43m",
+				},
+				{
+					CreatedAt:   "2024-04-16T09:08:25Z",
+					FinishedAt:  "2024-05-16T09:08:25Z",
+					SummaryTime: "720h0m",
+				},
+				{
+					CreatedAt:   "2024-08-11T11:25:00Z",
+					FinishedAt:  "2024-12-16T09:08:25Z",
+					SummaryTime: "3045h43m",
+				},
+				{
+					CreatedAt: "2024-12-16T09:08:25Z",
+				},
+			},
+		},
+		{
+			key: "by finished at ascending, running task goes last",
+			input: input{
+				id:   getUserID(postgres, 3),
+				sort: "finishedAt:asc",
+			},
+			expected: []task{
+				{
+					CreatedAt:   "2024-01-16T09:08:25Z",
+					FinishedAt:  "2024-01-16T16:10:00Z",
+					SummaryTime: "7h2m",
+				},
+				{
+					CreatedAt:   "2024-03-11T11:25:00Z",
+					FinishedAt:  "2024-05-11T09:08:25Z",
+					SummaryTime: "1461h43m",
+				},
+				{
+					CreatedAt:   "2024-04-16T09:08:25Z",
+					FinishedAt:  "2024-05-16T09:08:25Z",
+					SummaryTime: "720h0m",
+				},
+				{
+					CreatedAt:   "2024-08-11T11:25:00Z",
+					FinishedAt:  "2024-12-16T09:08:25Z",
+					SummaryTime: "3045h43m",
+				},
+				{
+					CreatedAt: "2024-12-16T09:08:25Z",
+				},
+			},
+		},
+		{
+			key: "by finished at descending, running task goes first",
+			input: input{
+				id:   getUserID(postgres, 3),
+				sort: "finishedAt:desc",
+			},
+			expected: []task{
+				{
+					CreatedAt: "2024-12-16T09:08:25Z",
+				},
+				{
+					CreatedAt:   "2024-08-11T11:25:00Z",
+					FinishedAt:  "2024-12-16T09:08:25Z",
+					SummaryTime: "3045h43m",
+				},
+				{
+					CreatedAt:   "2024-04-16T09:08:25Z",
+					FinishedAt:  "2024-05-16T09:08:25Z",
+					SummaryTime: "720h0m",
+				},
+				{
+					CreatedAt:   "2024-03-11T11:25:00Z",
+					FinishedAt:  "2024-05-11T09:08:25Z",
+					SummaryTime: "1461h43m",
+				},
+				{
+					CreatedAt:   "2024-01-16T09:08:25Z",
+					FinishedAt:  "2024-01-16T16:10:00Z",
+					SummaryTime: "7h2m",
+				},
+			},
+		},
+		{
+			key: "by summary time ascending",
+			input: input{
+				id:   getUserID(postgres, 3),
+				sort: "summaryTime:asc",
+			},
+			expected: []task{
+				{
+					CreatedAt:   "2024-01-16T09:08:25Z",
+					FinishedAt:  "2024-01-16T16:10:00Z",
+					SummaryTime: "7h2m",
+				},
+				{
+					CreatedAt:   "2024-04-16T09:08:25Z",
+					FinishedAt:  "2024-05-16T09:08:25Z",
+					SummaryTime: "720h0m",
+				},
+				{
+					CreatedAt:   "2024-03-11T11:25:00Z",
+					FinishedAt:  "2024-05-11T09:08:25Z",
+					SummaryTime: "1461h43m",
+				},
+				{
+					CreatedAt:   "2024-08-11T11:25:00Z",
+					FinishedAt:  "2024-12-16T09:08:25Z",
+					SummaryTime: "3045h43m",
+				},
+				{
+					CreatedAt: "2024-12-16T09:08:25Z",
+				},
+			},
+		},
+		{
+			key: "overlap clips straddling tasks",
+			input: input{
+				id:        getUserID(postgres, 2),
+				startTime: "2024-03-20T00:00:00Z",
+				endTime:   "2024-05-19T11:00:00Z",
+				mode:      "overlap",
+			},
+			expected: []task{
+				{
+					CreatedAt:   "2024-03-16T00:08:25Z",
+					FinishedAt:  "2024-03-24T00:00:00Z",
+					SummaryTime: "96h0m",
+				},
+				{
+					CreatedAt:   "2024-05-18T11:00:00Z",
+					FinishedAt:  "2024-05-20T09:08:25Z",
+					SummaryTime: "24h0m",
+				},
+			},
+		},
+		{
+			key: "overlap keeps running task",
+			input: input{
+				id:        getUserID(postgres, 3),
+				startTime: "2024-12-01T00:00:00Z",
+				endTime:   "2024-12-16T10:08:25Z",
+				mode:      "overlap",
+			},
+			expected: []task{
+				{
+					CreatedAt:   "2024-08-11T11:25:00Z",
+					FinishedAt:  "2024-12-16T09:08:25Z",
+					SummaryTime: "369h8m",
+				},
+				{
+					CreatedAt:   "2024-12-16T09:08:25Z",
+					SummaryTime: "1h0m",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			query := url.Values{}
+
+			query.Set("startTime", tc.input.startTime)
+			query.Set("endTime", tc.input.endTime)
+			query.Set("projectId", tc.input.projectID)
+			query.Set("sort", tc.input.sort)
+			query.Set("mode", tc.input.mode)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/summary-time/%s?%s", tc.input.id, query.Encode()), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+
+			tasks := make([]task, 0)
+			json.NewDecoder(recorder.Body).Decode(&tasks)
+
+			assert.Equal(t, tc.expected, tasks, tc.key)
+		})
+	}
+}
+
+func TestTaskSummaryTimeDurationFormat(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type input struct {
+		durationFormat string
+		rounding       string
+	}
+	type task struct {
+		ID                 string `json:"id"`
+		SummaryTime        string `json:"summaryTime"`
+		SummaryTimeSeconds *int64 `json:"summaryTimeSeconds"`
+		SummaryTimeISO     string `json:"summaryTimeIso"`
+	}
+
+	seconds := func(value int64) *int64 {
+		return &value
+	}
+
+	testCases := []struct {
+		key      string
+		input    input
+		expected []task
+	}{
+		{
+			key: "all without rounding",
+			input: input{
+				durationFormat: "all",
+				rounding:       "none",
+			},
+			expected: []task{
+				{
+					ID:                 getTaskID(postgres, 6),
+					SummaryTime:        "46h8m25s",
+					SummaryTimeSeconds: seconds(166105),
+					SummaryTimeISO:     "PT46H8M25S",
+				},
+				{
+					ID:                 getTaskID(postgres, 5),
+					SummaryTime:        "2h0m",
+					SummaryTimeSeconds: seconds(7200),
+					SummaryTimeISO:     "PT2H",
+				},
+			},
+		},
+		{
+			key: "seconds by quarter-hour",
+			input: input{
+				durationFormat: "seconds",
+				rounding:       "quarter-hour",
+			},
+			expected: []task{
+				{
+					ID:                 getTaskID(postgres, 6),
+					SummaryTime:        "46h15m",
+					SummaryTimeSeconds: seconds(166500),
+				},
+				{
+					ID:                 getTaskID(postgres, 5),
+					SummaryTime:        "2h0m",
+					SummaryTimeSeconds: seconds(7200),
+				},
+			},
+		},
+		{
+			key: "iso8601 by default rounding",
+			input: input{
+				durationFormat: "iso8601",
+			},
+			expected: []task{
+				{
+					ID:             getTaskID(postgres, 6),
+					SummaryTime:    "46h8m",
+					SummaryTimeISO: "PT46H8M",
+				},
+				{
+					ID:             getTaskID(postgres, 5),
+					SummaryTime:    "2h0m",
+					SummaryTimeISO: "PT2H",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			query := url.Values{}
+
+			query.Set("projectId", getProjectID(postgres, 0))
+			query.Set("durationFormat", tc.input.durationFormat)
+			query.Set("rounding", tc.input.rounding)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/summary-time/%s?%s", getUserID(postgres, 2), query.Encode()), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+
+			tasks := make([]task, 0)
+			json.NewDecoder(recorder.Body).Decode(&tasks)
+
+			assert.Equal(t, tc.expected, tasks, tc.key)
+		})
+	}
+}
+
+func TestTaskSummaryTimeDurationFormatRunning(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type task struct {
+		ID                 string `json:"id"`
+		SummaryTime        string `json:"summaryTime"`
+		SummaryTimeSeconds *int64 `json:"summaryTimeSeconds"`
+		SummaryTimeISO     string `json:"summaryTimeIso"`
+	}
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/summary-time/%s?durationFormat=all", getUserID(postgres, 4)), nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	tasks := make([]task, 0)
+	json.NewDecoder(recorder.Body).Decode(&tasks)
+
+	for _, task := range tasks {
+		if task.ID != getTaskID(postgres, 9) {
+			assert.NotNil(t, task.SummaryTimeSeconds, task.ID)
+			continue
+		}
+
+		assert.Equal(t, "", task.SummaryTime, "running task")
+		assert.Nil(t, task.SummaryTimeSeconds, "running task")
+		assert.Equal(t, "", task.SummaryTimeISO, "running task")
+	}
+}
+
+func TestTaskSummaryTimePagination(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type task struct {
+		ID        string `json:"id"`
+		CreatedAt string `json:"createdAt"`
+	}
+
+	t.Run("offset with sort", func(t *testing.T) {
+		query := url.Values{}
+
+		query.Set("sort", "createdAt:asc")
+		query.Set("limit", "2")
+		query.Set("offset", "2")
+
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/summary-time/%s?%s", getUserID(postgres, 3), query.Encode()), nil)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "5", recorder.Header().Get("X-Total-Count"))
+		assert.Empty(t, recorder.Header().Get("X-Next-Cursor"))
+
+		tasks := make([]task, 0)
+		json.NewDecoder(recorder.Body).Decode(&tasks)
+
+		if assert.Len(t, tasks, 2) {
+			assert.Equal(t, "2024-04-16T09:08:25Z", tasks[0].CreatedAt)
+			assert.Equal(t, "2024-08-11T11:25:00Z", tasks[1].CreatedAt)
+		}
+	})
+
+	t.Run("limit keeps default order", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/summary-time/%s?limit=2", getUserID(postgres, 3)), nil)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("X-Next-Cursor"))
+
+		tasks := make([]task, 0)
+		json.NewDecoder(recorder.Body).Decode(&tasks)
+
+		if assert.Len(t, tasks, 2) {
+			assert.Equal(t, getTaskID(postgres, 3), tasks[0].ID, "running task goes first in summary time desc order")
+		}
+	})
+
+	t.Run("cursor goes through all tasks", func(t *testing.T) {
+		ids := make(map[string]struct{})
+		sizes := make([]int, 0)
+		cursor := ""
+
+		for {
+			query := url.Values{}
+
+			query.Set("limit", "2")
+
+			if cursor == "" {
+				query.Set("sort", "id:asc")
+			} else {
+				query.Set("cursor", cursor)
+			}
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/summary-time/%s?%s", getUserID(postgres, 3), query.Encode()), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			if !assert.Equal(t, http.StatusOK, recorder.Code) {
+				return
+			}
+
+			assert.Equal(t, "5", recorder.Header().Get("X-Total-Count"))
+
+			tasks := make([]task, 0)
+			json.NewDecoder(recorder.Body).Decode(&tasks)
+
+			sizes = append(sizes, len(tasks))
+			for _, task := range tasks {
+				ids[task.ID] = struct{}{}
+			}
+
+			cursor = recorder.Header().Get("X-Next-Cursor")
+			if cursor == "" {
+				break
+			}
+
+			assert.Equal(t, base64.RawURLEncoding.EncodeToString([]byte(tasks[len(tasks)-1].ID)), cursor)
+			assert.Contains(t, recorder.Header().Get("Link"), `rel="next"`)
+		}
+
+		assert.Equal(t, []int{2, 2, 1}, sizes)
+		assert.Len(t, ids, 5)
+	})
+}
+
+func TestTaskSummaryTimeNegative(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type input struct {
+		id             string
+		startTime      string
+		endTime        string
+		groupBy        string
+		format         string
+		delimiter      string
+		durationFormat string
+		rounding       string
+		tz             string
+		sort           string
+		mode           string
+		limit          string
+		offset         string
+		cursor         string
+	}
+
+	testCases := []struct {
+		key          string
+		input        input
+		expectedCode int
+	}{
+		{
+			key: "there's in no user with that id",
+			input: input{
+				id: "1ef44ce4-6afb-6da0-9e4e-6ea3cb7df39c",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "case 1 wrong id type",
+			input: input{
+				id: "1ef44ce4-6da0-9e4e-6ea3cb7df39c",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "case 2 wrong id type",
+			input: input{
+				id: "2",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "miss start time",
+			input: input{
+				id:        getUserID(postgres, 3),
+				startTime: "2025-02-01T00:00:00Z",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "miss end time",
+			input: input{
+				id:      getUserID(postgres, 3),
+				endTime: "2023-02-01T00:00:00Z",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "miss start and end time",
+			input: input{
+				id:        getUserID(postgres, 3),
+				startTime: "2022-02-01T00:00:00Z",
+				endTime:   "2023-02-01T00:00:00Z",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "unknown group by",
+			input: input{
+				id:      getUserID(postgres, 3),
+				groupBy: "year",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "unknown rounding",
+			input: input{
+				id:       getUserID(postgres, 3),
+				rounding: "hour",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "unknown time zone",
+			input: input{
+				id: getUserID(postgres, 3),
+				tz: "Mars/Olympus_Mons",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "unknown sort field",
+			input: input{
+				id:   getUserID(postgres, 3),
+				sort: "title:asc",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "sort without direction",
+			input: input{
+				id:   getUserID(postgres, 3),
+				sort: "createdAt",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "unknown mode",
+			input: input{
+				id:   getUserID(postgres, 3),
+				mode: "intersect",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "contained mode misses straddling tasks",
+			input: input{
+				id:        getUserID(postgres, 2),
+				startTime: "2024-03-20T00:00:00Z",
+				endTime:   "2024-05-19T11:00:00Z",
+				mode:      "contained",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "wrong limit",
+			input: input{
+				id:    getUserID(postgres, 3),
+				limit: "ten",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "wrong cursor",
+			input: input{
+				id:     getUserID(postgres, 3),
+				cursor: "2",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "overlap with task finished at start time",
+			input: input{
+				id:        getUserID(postgres, 3),
+				startTime: "2024-01-16T16:10:00Z",
+				endTime:   "2024-02-01T00:00:00Z",
+				mode:      "overlap",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "raw task id as cursor",
+			input: input{
+				id:     getUserID(postgres, 3),
+				cursor: "1ef44ce4-6afb-6da0-9e4e-6ea3cb7df39c",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "cursor with sort",
+			input: input{
+				id:     getUserID(postgres, 3),
+				sort:   "createdAt:asc",
+				cursor: base64.RawURLEncoding.EncodeToString([]byte("1ef44ce4-6afb-6da0-9e4e-6ea3cb7df39c")),
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "cursor with offset",
+			input: input{
+				id:     getUserID(postgres, 3),
+				offset: "2",
+				cursor: base64.RawURLEncoding.EncodeToString([]byte("1ef44ce4-6afb-6da0-9e4e-6ea3cb7df39c")),
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "offset out of tasks",
+			input: input{
+				id:     getUserID(postgres, 3),
+				limit:  "10",
+				offset: "5",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "unknown format",
+			input: input{
+				id:     getUserID(postgres, 3),
+				format: "xml",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "too long delimiter",
+			input: input{
+				id:        getUserID(postgres, 3),
+				format:    "csv",
+				delimiter: ";;",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "csv with duration format",
+			input: input{
+				id:             getUserID(postgres, 2),
+				format:         "csv",
+				durationFormat: "seconds",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "csv with miss start time",
+			input: input{
+				id:        getUserID(postgres, 3),
+				startTime: "2025-02-01T00:00:00Z",
+				format:    "csv",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "group by with miss start time",
+			input: input{
+				id:        getUserID(postgres, 3),
+				startTime: "2025-02-01T00:00:00Z",
+				groupBy:   "day",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			query := url.Values{}
+
+			query.Set("startTime", tc.input.startTime)
+			query.Set("endTime", tc.input.endTime)
+			query.Set("groupBy", tc.input.groupBy)
+			query.Set("format", tc.input.format)
+			query.Set("delimiter", tc.input.delimiter)
+			query.Set("durationFormat", tc.input.durationFormat)
+			query.Set("rounding", tc.input.rounding)
+			query.Set("tz", tc.input.tz)
+			query.Set("sort", tc.input.sort)
+			query.Set("mode", tc.input.mode)
+			query.Set("limit", tc.input.limit)
+			query.Set("offset", tc.input.offset)
+			query.Set("cursor", tc.input.cursor)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/summary-time/%s?%s", tc.input.id, query.Encode()), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+		})
+	}
+}
+
+func TestTaskSummaryTimeGroupByPositive(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		postgres.Close()
+	})
+
+	type input struct {
+		id        string
+		groupBy   string
+		projectID string
+		tz        string
+	}
+	type period struct {
+		Period      string `json:"period"`
+		TasksCount  int    `json:"tasksCount"`
+		SummaryTime string `json:"summaryTime"`
+	}
+	type report struct {
+		Periods     []period `json:"periods"`
+		TasksCount  int      `json:"tasksCount"`
+		SummaryTime string   `json:"summaryTime"`
+	}
+
+	testCases := []struct {
+		key      string
+		input    input
+		expected report
+	}{
+		{
+			key: "by month",
+			input: input{
+				id:      getUserID(postgres, 2),
+				groupBy: "month",
+			},
+			expected: report{
+				Periods: []period{
+					{
+						Period:      "2024-01-01T00:00:00Z",
+						TasksCount:  1,
+						SummaryTime: "2h8m",
+					},
+					{
+						Period:      "2024-03-01T00:00:00Z",
+						TasksCount:  1,
+						SummaryTime: "191h52m",
+					},
+					{
+						Period:      "2024-05-01T00:00:00Z",
+						TasksCount:  1,
+						SummaryTime: "46h8m",
+					},
+					{
+						Period:      "2024-11-01T00:00:00Z",
+						TasksCount:  1,
+						SummaryTime: "2h0m",
+					},
+				},
+				TasksCount:  4,
+				SummaryTime: "242h8m",
+			},
+		},
+		{
+			key: "by week",
+			input: input{
+				id:      getUserID(postgres, 2),
+				groupBy: "week",
+			},
+			expected: report{
+				Periods: []period{
+					{
+						Period:      "2024-01-15T00:00:00Z",
+						TasksCount:  1,
+						SummaryTime: "2h8m",
+					},
+					{
+						Period:      "2024-03-11T00:00:00Z",
+						TasksCount:  1,
+						SummaryTime: "191h52m",
+					},
+					{
+						Period:      "2024-05-13T00:00:00Z",
+						TasksCount:  1,
+						SummaryTime: "46h8m",
+					},
+					{
+						Period:      "2024-11-11T00:00:00Z",
+						TasksCount:  1,
+						SummaryTime: "2h0m",
+					},
+				},
+				TasksCount:  4,
+				SummaryTime: "242h8m",
+			},
 		},
 		{
-			key:    "case 2",
-			userID: getUserID(postgres, 1),
+			key: "by day with project",
+			input: input{
+				id:        getUserID(postgres, 2),
+				groupBy:   "day",
+				projectID: getProjectID(postgres, 0),
+			},
+			expected: report{
+				Periods: []period{
+					{
+						Period:      "2024-05-18T00:00:00Z",
+						TasksCount:  1,
+						SummaryTime: "46h8m",
+					},
+					{
+						Period:      "2024-11-16T00:00:00Z",
+						TasksCount:  1,
+						SummaryTime: "2h0m",
+					},
+				},
+				TasksCount:  2,
+				SummaryTime: "48h8m",
+			},
 		},
 		{
-			key:    "case 3",
-			userID: getUserID(postgres, 2),
+			key: "by day in time zone",
+			input: input{
+				id:      getUserID(postgres, 2),
+				groupBy: "day",
+				tz:      "America/New_York",
+			},
+			expected: report{
+				Periods: []period{
+					{
+						Period:      "2024-01-16T00:00:00-05:00",
+						TasksCount:  1,
+						SummaryTime: "2h8m",
+					},
+					{
+						Period:      "2024-03-15T00:00:00-04:00",
+						TasksCount:  1,
+						SummaryTime: "191h52m",
+					},
+					{
+						Period:      "2024-05-18T00:00:00-04:00",
+						TasksCount:  1,
+						SummaryTime: "46h8m",
+					},
+					{
+						Period:      "2024-11-16T00:00:00-05:00",
+						TasksCount:  1,
+						SummaryTime: "2h0m",
+					},
+				},
+				TasksCount:  4,
+				SummaryTime: "242h8m",
+			},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.key, func(t *testing.T) {
-			req, _ := http.NewRequest("POST", fmt.Sprintf("/v1/tasks/start/%s", tc.userID), nil)
+			query := url.Values{}
+
+			query.Set("groupBy", tc.input.groupBy)
+			query.Set("projectId", tc.input.projectID)
+			query.Set("tz", tc.input.tz)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/summary-time/%s?%s", tc.input.id, query.Encode()), nil)
 			recorder := httptest.NewRecorder()
 			handler.ServeHTTP(recorder, req)
 
-			assert.Equal(t, http.StatusCreated, recorder.Code, tc.key)
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+
+			report := report{}
+			json.NewDecoder(recorder.Body).Decode(&report)
+
+			assert.Equal(t, tc.expected, report, tc.key)
 		})
 	}
 }
 
-func TestTaskStartNegative(t *testing.T) {
+func TestTaskSummaryTimeCSV(t *testing.T) {
 	postgres, handler := prepare()
 	t.Cleanup(func() {
-		defer postgres.Close()
+		postgres.Close()
 	})
 
+	type input struct {
+		id        string
+		projectID string
+		format    string
+		accept    string
+		delimiter string
+		rounding  string
+	}
+
+	userID := getUserID(postgres, 2)
+	projectID := getProjectID(postgres, 0)
+
 	testCases := []struct {
-		key          string
-		userID       string
-		expectedCode int
+		key      string
+		input    input
+		expected string
 	}{
 		{
-			key:          "there's no user with that id",
-			userID:       "1ef44bc9-5c77-6c90-a41a-1f1b0b522ea3",
-			expectedCode: http.StatusNoContent,
+			key: "by accept header",
+			input: input{
+				id:        userID,
+				projectID: projectID,
+				accept:    "text/csv",
+			},
+			expected: fmt.Sprintf(`userId,surname,name,taskId,title,description,labels,projectId,createdAt,finishedAt,summaryTime
+%[1]s,McCullough,Jessie,%[2]s,,,,%[4]s,2024-05-18T11:00:00Z,2024-05-20T09:08:25Z,46h8m
+%[1]s,McCullough,Jessie,%[3]s,,,,%[4]s,2024-11-16T07:08:25Z,2024-11-16T09:08:25Z,2h0m
+%[1]s,McCullough,Jessie,,Total: 2 tasks,,,,,,48h8m
+`, userID, getTaskID(postgres, 6), getTaskID(postgres, 5), projectID),
 		},
 		{
-			key:          "not correct type of id case 1",
-			userID:       "5a55-6710-a9cf-ddslfjkjjj2e",
-			expectedCode: http.StatusBadRequest,
+			key: "by format with delimiter",
+			input: input{
+				id:        userID,
+				projectID: projectID,
+				format:    "csv",
+				delimiter: ";",
+			},
+			expected: fmt.Sprintf(`userId;surname;name;taskId;title;description;labels;projectId;createdAt;finishedAt;summaryTime
+%[1]s;McCullough;Jessie;%[2]s;;;;%[4]s;2024-05-18T11:00:00Z;2024-05-20T09:08:25Z;46h8m
+%[1]s;McCullough;Jessie;%[3]s;;;;%[4]s;2024-11-16T07:08:25Z;2024-11-16T09:08:25Z;2h0m
+%[1]s;McCullough;Jessie;;Total: 2 tasks;;;;;;48h8m
+`, userID, getTaskID(postgres, 6), getTaskID(postgres, 5), projectID),
 		},
 		{
-			key:          "not correct type of id case 2",
-			userID:       "1",
-			expectedCode: http.StatusBadRequest,
+			key: "with rounding",
+			input: input{
+				id:        userID,
+				projectID: projectID,
+				format:    "csv",
+				rounding:  "quarter-hour",
+			},
+			expected: fmt.Sprintf(`userId,surname,name,taskId,title,description,labels,projectId,createdAt,finishedAt,summaryTime
+%[1]s,McCullough,Jessie,%[2]s,,,,%[4]s,2024-05-18T11:00:00Z,2024-05-20T09:08:25Z,46h15m
+%[1]s,McCullough,Jessie,%[3]s,,,,%[4]s,2024-11-16T07:08:25Z,2024-11-16T09:08:25Z,2h0m
+%[1]s,McCullough,Jessie,,Total: 2 tasks,,,,,,48h15m
+`, userID, getTaskID(postgres, 6), getTaskID(postgres, 5), projectID),
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.key, func(t *testing.T) {
-			req, _ := http.NewRequest("POST", fmt.Sprintf("/v1/tasks/start/%s", tc.userID), nil)
+			query := url.Values{}
+
+			query.Set("projectId", tc.input.projectID)
+			query.Set("format", tc.input.format)
+			query.Set("delimiter", tc.input.delimiter)
+			query.Set("rounding", tc.input.rounding)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/summary-time/%s?%s", tc.input.id, query.Encode()), nil)
+			req.Header.Set("Accept", tc.input.accept)
 			recorder := httptest.NewRecorder()
 			handler.ServeHTTP(recorder, req)
 
-			assert.Equal(t, tc.expectedCode, recorder.Code, tc.key)
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+			assert.Equal(t, "text/csv", recorder.Header().Get("Content-Type"), tc.key)
+			assert.Equal(t, fmt.Sprintf(`attachment; filename="summary-time-%s.csv"`, tc.input.id), recorder.Header().Get("Content-Disposition"), tc.key)
+			assert.Equal(t, strings.ReplaceAll(tc.expected, "\n", "\r\n"), recorder.Body.String(), tc.key)
 		})
 	}
 }
 
-func TestTaskEndPositive(t *testing.T) {
+func TestTaskCalendarPositive(t *testing.T) {
 	postgres, handler := prepare()
 	t.Cleanup(func() {
-		defer postgres.Close()
+		postgres.Close()
 	})
 
+	type input struct {
+		id        string
+		startTime string
+	}
+
 	testCases := []struct {
-		key    string
-		userID string
+		key      string
+		input    input
+		expected string
 	}{
 		{
-			key:    "case 1",
-			userID: getTaskID(postgres, 0),
+			key: "with start time",
+			input: input{
+				id:        getUserID(postgres, 2),
+				startTime: "2024-05-01T00:00:00Z",
+			},
+			expected: strings.Join([]string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//time-tracker//tasks//EN",
+				"CALSCALE:GREGORIAN",
+				"BEGIN:VEVENT",
+				"UID:" + getTaskID(postgres, 6),
+				"DTSTAMP:20240520T090825Z",
+				"DTSTART:20240518T110000Z",
+				"DTEND:20240520T090825Z",
+				"SUMMARY:",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:" + getTaskID(postgres, 5),
+				"DTSTAMP:20241116T090825Z",
+				"DTSTART:20241116T070825Z",
+				"DTEND:20241116T090825Z",
+				"SUMMARY:",
+				"END:VEVENT",
+				"END:VCALENDAR",
+				"",
+			}, "\r\n"),
 		},
 		{
-			key:    "case 2",
-			userID: getTaskID(postgres, 1),
+			key: "running task is omitted",
+			input: input{
+				id:        getUserID(postgres, 3),
+				startTime: "2024-12-01T00:00:00Z",
+			},
+			expected: strings.Join([]string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//time-tracker//tasks//EN",
+				"CALSCALE:GREGORIAN",
+				"END:VCALENDAR",
+				"",
+			}, "\r\n"),
 		},
 		{
-			key:    "case 3",
-			userID: getTaskID(postgres, 2),
+			key: "there's no any tasks",
+			input: input{
+				id: "1ef44ce4-6afb-6da0-9e4e-6ea3cb7df39c",
+			},
+			expected: strings.Join([]string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//time-tracker//tasks//EN",
+				"CALSCALE:GREGORIAN",
+				"END:VCALENDAR",
+				"",
+			}, "\r\n"),
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.key, func(t *testing.T) {
-			req, _ := http.NewRequest("PATCH", fmt.Sprintf("/v1/tasks/end/%s", tc.userID), nil)
+			query := url.Values{}
+
+			query.Set("startTime", tc.input.startTime)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/calendar/%s.ics?%s", tc.input.id, query.Encode()), nil)
 			recorder := httptest.NewRecorder()
 			handler.ServeHTTP(recorder, req)
 
 			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+			assert.Equal(t, "text/calendar; charset=utf-8", recorder.Header().Get("Content-Type"), tc.key)
+			assert.Equal(t, tc.expected, recorder.Body.String(), tc.key)
 		})
 	}
 }
 
-func TestTaskEndNegative(t *testing.T) {
+func TestTaskCalendarNegative(t *testing.T) {
 	postgres, handler := prepare()
 	t.Cleanup(func() {
-		defer postgres.Close()
+		postgres.Close()
 	})
 
 	testCases := []struct {
 		key          string
-		userID       string
+		file         string
 		expectedCode int
 	}{
 		{
-			key:          "there's no user with that id",
-			userID:       "1ef44bc9-5c77-6c90-a41a-1f1b0b522ea3",
-			expectedCode: http.StatusNoContent,
-		},
-		{
-			key:          "case 1 not correct type of id",
-			userID:       "5a55-6710-a9cf-ddslfjkjjj2e",
+			key:          "without extension",
+			file:         getUserID(postgres, 2),
 			expectedCode: http.StatusBadRequest,
 		},
 		{
-			key:          "case 2 not correct type of id",
-			userID:       "1",
+			key:          "wrong id type",
+			file:         "2.ics",
 			expectedCode: http.StatusBadRequest,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.key, func(t *testing.T) {
-			req, _ := http.NewRequest("PATCH", fmt.Sprintf("/v1/tasks/end/%s", tc.userID), nil)
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/calendar/%s", tc.file), nil)
 			recorder := httptest.NewRecorder()
 			handler.ServeHTTP(recorder, req)
 
@@ -159,10 +2370,10 @@ func TestTaskEndNegative(t *testing.T) {
 	}
 }
 
-func TestTaskSummaryTimePositive(t *testing.T) {
+func TestTaskSummaryTimeByProjectPositive(t *testing.T) {
 	postgres, handler := prepare()
 	t.Cleanup(func() {
-		defer postgres.Close()
+		postgres.Close()
 	})
 
 	type input struct {
@@ -170,139 +2381,66 @@ func TestTaskSummaryTimePositive(t *testing.T) {
 		startTime string
 		endTime   string
 	}
-	type task struct {
-		CreatedAt   string `json:"createdAt"`
-		FinishedAt  string `json:"finishedAt"`
+	type project struct {
+		ProjectID   string `json:"projectId"`
+		ProjectName string `json:"projectName"`
+		TasksCount  int    `json:"tasksCount"`
 		SummaryTime string `json:"summaryTime"`
 	}
 
 	testCases := []struct {
 		key      string
 		input    input
-		expected []task
+		expected []project
 	}{
-		{
-			key: "tasks' 4th user",
-			input: input{
-				id: getUserID(postgres, 3),
-			},
-			expected: []task{
-				{
-					CreatedAt: "2024-12-16T09:08:25Z",
-				},
-				{
-					CreatedAt: "2024-08-11T11:25:00Z",
-				},
-				{
-					CreatedAt:   "2024-03-11T11:25:00Z",
-					FinishedAt:  "2024-05-11T09:08:25Z",
-					SummaryTime: "1461h43m",
-				},
-				{
-					CreatedAt:   "2024-04-16T09:08:25Z",
-					FinishedAt:  "2024-05-16T09:08:25Z",
-					SummaryTime: "720h0m",
-				},
-				{
-					CreatedAt:   "2024-01-16T09:08:25Z",
-					FinishedAt:  "2024-01-16T16:10:00Z",
-					SummaryTime: "7h2m",
-				},
-			},
-		},
 		{
 			key: "tasks' 3rd user",
 			input: input{
 				id: getUserID(postgres, 2),
 			},
-			expected: []task{
+			expected: []project{
 				{
-					CreatedAt:   "2024-03-16T00:08:25Z",
-					FinishedAt:  "2024-03-24T00:00:00Z",
+					TasksCount:  1,
 					SummaryTime: "191h52m",
 				},
 				{
-					CreatedAt:   "2024-05-18T11:00:00Z",
-					FinishedAt:  "2024-05-20T09:08:25Z",
-					SummaryTime: "46h8m",
+					ProjectID:   getProjectID(postgres, 0),
+					ProjectName: "Acme website",
+					TasksCount:  2,
+					SummaryTime: "48h8m",
 				},
 				{
-					CreatedAt:   "2024-01-16T07:00:25Z",
-					FinishedAt:  "2024-01-16T09:08:25Z",
+					ProjectID:   getProjectID(postgres, 1),
+					ProjectName: "Internal tooling",
+					TasksCount:  1,
 					SummaryTime: "2h8m",
 				},
-				{
-					CreatedAt:   "2024-11-16T07:08:25Z",
-					FinishedAt:  "2024-11-16T09:08:25Z",
-					SummaryTime: "2h0m",
-				},
-			},
-		},
-		{
-			key: "tasks' 5 user",
-			input: input{
-				id: getUserID(postgres, 4),
-			},
-			expected: []task{
-				{
-					CreatedAt: "2024-12-16T09:08:25Z",
-				},
-				{
-					CreatedAt: "2024-08-11T11:25:00Z",
-				},
 			},
 		},
 		{
 			key: "with start time",
 			input: input{
-				id:        getUserID(postgres, 3),
-				startTime: "2024-04-01T00:00:00Z",
-			},
-			expected: []task{
-				{
-					CreatedAt: "2024-12-16T09:08:25Z",
-				},
-				{
-					CreatedAt: "2024-08-11T11:25:00Z",
-				},
-				{
-					CreatedAt:   "2024-04-16T09:08:25Z",
-					FinishedAt:  "2024-05-16T09:08:25Z",
-					SummaryTime: "720h0m",
-				},
-			},
-		},
-		{
-			key: "with end time",
-			input: input{
-				id:      getUserID(postgres, 3),
-				endTime: "2024-05-12T00:00:00Z",
+				id:        getUserID(postgres, 2),
+				startTime: "2024-05-01T00:00:00Z",
 			},
-			expected: []task{
-				{
-					CreatedAt:   "2024-03-11T11:25:00Z",
-					FinishedAt:  "2024-05-11T09:08:25Z",
-					SummaryTime: "1461h43m",
-				},
+			expected: []project{
 				{
-					CreatedAt:   "2024-01-16T09:08:25Z",
-					FinishedAt:  "2024-01-16T16:10:00Z",
-					SummaryTime: "7h2m",
+					ProjectID:   getProjectID(postgres, 0),
+					ProjectName: "Acme website",
+					TasksCount:  2,
+					SummaryTime: "48h8m",
 				},
 			},
 		},
 		{
-			key: "with start and end time",
+			key: "tasks' 5 user",
 			input: input{
-				id:        getUserID(postgres, 3),
-				startTime: "2024-02-01T00:00:00Z",
-				endTime:   "2024-05-12T00:00:00Z",
+				id: getUserID(postgres, 4),
 			},
-			expected: []task{
+			expected: []project{
 				{
-					CreatedAt:   "2024-03-11T11:25:00Z",
-					FinishedAt:  "2024-05-11T09:08:25Z",
-					SummaryTime: "1461h43m",
+					TasksCount:  2,
+					SummaryTime: "3045h43m",
 				},
 			},
 		},
@@ -315,93 +2453,57 @@ func TestTaskSummaryTimePositive(t *testing.T) {
 			query.Set("startTime", tc.input.startTime)
 			query.Set("endTime", tc.input.endTime)
 
-			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/summary-time/%s?%s", tc.input.id, query.Encode()), nil)
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/summary-time/%s/projects?%s", tc.input.id, query.Encode()), nil)
 			recorder := httptest.NewRecorder()
 			handler.ServeHTTP(recorder, req)
 
 			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
 
-			tasks := make([]task, 0)
-			json.NewDecoder(recorder.Body).Decode(&tasks)
+			projects := make([]project, 0)
+			json.NewDecoder(recorder.Body).Decode(&projects)
 
-			assert.Equal(t, tc.expected, tasks, tc.key)
+			assert.Equal(t, tc.expected, projects, tc.key)
 		})
 	}
 }
 
-func TestTaskSummaryTimeNegative(t *testing.T) {
+func TestTaskSummaryTimeByProjectNegative(t *testing.T) {
 	postgres, handler := prepare()
 	t.Cleanup(func() {
 		postgres.Close()
 	})
 
-	type input struct {
-		id        string
-		startTime string
-		endTime   string
-	}
-
 	testCases := []struct {
 		key          string
-		input        input
+		id           string
+		projectID    string
 		expectedCode int
 	}{
 		{
-			key: "there's in no user with that id",
-			input: input{
-				id: "1ef44ce4-6afb-6da0-9e4e-6ea3cb7df39c",
-			},
+			key:          "there's in no user with that id",
+			id:           "1ef44ce4-6afb-6da0-9e4e-6ea3cb7df39c",
 			expectedCode: http.StatusNoContent,
 		},
 		{
-			key: "case 1 wrong id type",
-			input: input{
-				id: "1ef44ce4-6da0-9e4e-6ea3cb7df39c",
-			},
+			key:          "wrong id type",
+			id:           "2",
 			expectedCode: http.StatusBadRequest,
 		},
 		{
-			key: "case 2 wrong id type",
-			input: input{
-				id: "2",
-			},
+			key:          "wrong project id type",
+			id:           getUserID(postgres, 2),
+			projectID:    "2",
 			expectedCode: http.StatusBadRequest,
 		},
-		{
-			key: "miss start time",
-			input: input{
-				id:        getUserID(postgres, 3),
-				startTime: "2025-02-01T00:00:00Z",
-			},
-			expectedCode: http.StatusNoContent,
-		},
-		{
-			key: "miss end time",
-			input: input{
-				id:      getUserID(postgres, 3),
-				endTime: "2023-02-01T00:00:00Z",
-			},
-			expectedCode: http.StatusNoContent,
-		},
-		{
-			key: "miss start and end time",
-			input: input{
-				id:        getUserID(postgres, 3),
-				startTime: "2022-02-01T00:00:00Z",
-				endTime:   "2023-02-01T00:00:00Z",
-			},
-			expectedCode: http.StatusNoContent,
-		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.key, func(t *testing.T) {
 			query := url.Values{}
 
-			query.Set("startTime", tc.input.startTime)
-			query.Set("endTime", tc.input.endTime)
+			query.Set("projectId", tc.projectID)
 
-			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/summary-time/%s?%s", tc.input.id, query.Encode()), nil)
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/tasks/summary-time/%s/projects?%s", tc.id, query.Encode()), nil)
 			recorder := httptest.NewRecorder()
 			handler.ServeHTTP(recorder, req)
 
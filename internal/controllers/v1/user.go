@@ -3,6 +3,7 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/v1adhope/time-tracker/internal/entities"
@@ -31,6 +32,7 @@ type createUserReq struct {
 	Patronymic     string `json:"patronymic" binding:"required,alphabetical,max=255" example:"Robertovich"`
 	Address        string `json:"address" binding:"required,ascii,max=255" example:"1123 Ola Brook"`
 	PassportNumber string `json:"passportNumber" binding:"required,passport" example:"6666 666666"`
+	Timezone       string `json:"timezone" binding:"omitempty,timezone" example:"Asia/Vladivostok"`
 }
 
 // @tags users
@@ -56,6 +58,7 @@ func (r *userRouter) Create(c *gin.Context) {
 		Patronymic:     req.Patronymic,
 		Address:        req.Address,
 		PassportNumber: req.PassportNumber,
+		Timezone:       req.Timezone,
 	})
 	if err != nil {
 		setAnyError(c, err)
@@ -105,6 +108,7 @@ type updateUserReq struct {
 	Patronymic     string `json:"patronymic" example:"Victorovich"`
 	Address        string `json:"address" example:"516 Carlee Statio"`
 	PassportNumber string `json:"passportNumber" binding:"passport" example:"7777 777777"`
+	Timezone       string `json:"timezone" binding:"omitempty,timezone" example:"Europe/Moscow"`
 }
 
 // @tags users
@@ -139,6 +143,7 @@ func (r *userRouter) Update(c *gin.Context) {
 		Patronymic:     req.Patronymic,
 		Address:        req.Address,
 		PassportNumber: req.PassportNumber,
+		Timezone:       req.Timezone,
 	}); err != nil {
 		setAnyError(c, err)
 		return
@@ -148,27 +153,47 @@ func (r *userRouter) Update(c *gin.Context) {
 }
 
 type allUserQuery struct {
-	ID             string `form:"id" binding:"omitempty,uuid" exmaple:"ef4f145-727e-6b60-ae1e-393b41b8e97b"`
-	Surname        string `form:"surname" binding:"omitempty,filterstring"`
-	Name           string `form:"name" binding:"omitempty,filterstring"`
-	Patronymic     string `form:"patronymic" binding:"omitempty,filterstring"`
-	Address        string `form:"address" binding:"omitempty,filterstring"`
-	PassportNumber string `form:"passportNumber" binding:"omitempty,filterstring"`
+	ID             string          `form:"id" binding:"omitempty,uuid" exmaple:"ef4f145-727e-6b60-ae1e-393b41b8e97b"`
+	Surname        entities.Filter `form:"surname"`
+	Name           entities.Filter `form:"name"`
+	Patronymic     entities.Filter `form:"patronymic"`
+	Address        entities.Filter `form:"address"`
+	PassportNumber entities.Filter `form:"passportNumber"`
+	Q              string          `form:"q" binding:"omitempty,max=255"`
 
 	Limit  string `form:"limit" binding:"omitempty,number"`
 	Offset string `form:"offset" binding:"omitempty,number"`
+	Cursor string `form:"cursor" binding:"omitempty,cursor,excluded_with=Offset Sort Q"`
+	Sort   string `form:"sort" binding:"omitempty,usersort"`
+	Format string `form:"format" binding:"omitempty,oneof=list envelope"`
+}
+
+type allUserEnvelope struct {
+	Items  []entities.User `json:"items"`
+	Total  int             `json:"total" example:"12"`
+	Limit  uint64          `json:"limit" example:"10"`
+	Offset uint64          `json:"offset" example:"0"`
 }
 
 // @tags users
 // @summary Get all users
+// @description Users go in order of relevance with q, then sort, then id. Without sort and q a full page has X-Next-Cursor and Link headers to the next one
 // @param id query string false "Find by id (uuid)"
 // @param limit query uint64 false "Pagination control"
 // @param offset query uint64 false "Pagination control"
-// @param surname query string false "Custom type consitst operation:value. Allowed operations eq, ilike"
-// @param name query string false "Custom type consitst operation:value. Allowed operations eq, ilike"
-// @param address query string false "Custom type consitst operation:value. Allowed operations eq, ilike"
-// @param passportNumber query string false "Custom type consitst operation:value. Allowed operations eq, ilike"
+// @param q query string false "Fuzzy search over surname, name, patronymic and address, tolerates typos and partial words. Adds score of relevance"
+// @param cursor query string false "Opaque cursor from X-Next-Cursor of the previous page. Can't be used with offset, sort and q"
+// @param format query string false "list is a plain array, envelope wraps the page with total, limit and offset, list by default" Enums(list, envelope)
+// @param sort query string false "Comma list of id, surname, name, patronymic, address, passportNumber, minus prefix sorts descending, e.g. surname,-name"
+// @param surname query string false "Custom type consitst operation:value. Allowed operations eq, neq, in (comma list), prefix, suffix, ilike, notilike"
+// @param name query string false "Custom type consitst operation:value. Allowed operations eq, neq, in (comma list), prefix, suffix, ilike, notilike"
+// @param address query string false "Custom type consitst operation:value. Allowed operations eq, neq, in (comma list), prefix, suffix, ilike, notilike"
+// @param passportNumber query string false "Custom type consitst operation:value. Allowed operations eq, neq, in (comma list), prefix, suffix, ilike, notilike"
 // @response 200 {object} []entities.User
+// @response 200 {object} allUserEnvelope "With format=envelope"
+// @header 200 {integer} X-Total-Count "Count of all users matching the filter"
+// @header 200 {string} X-Next-Cursor "Cursor of the next page, only for a full page"
+// @header 200 {string} Link "Link to the next page with rel=next, only for a full page"
 // @response 204 "No any users by this request"
 // @response 400
 // @response 500
@@ -181,11 +206,13 @@ func (r *userRouter) All(c *gin.Context) {
 		return
 	}
 
-	users, err := r.userUsecase.GetAll(c.Request.Context(), entities.UserRepresentation{
+	page, err := r.userUsecase.GetAll(c.Request.Context(), entities.UserRepresentation{
 		Pagination: entities.UserPagination{
 			Limit:  query.Limit,
 			Offset: query.Offset,
+			Cursor: decodeCursor(query.Cursor),
 		},
+		Sort: parseUserSort(query.Sort),
 		Filter: entities.UserFilter{
 			ByID:             query.ID,
 			BySurname:        query.Surname,
@@ -193,6 +220,7 @@ func (r *userRouter) All(c *gin.Context) {
 			ByPatronymic:     query.Patronymic,
 			ByAddress:        query.Address,
 			ByPassportNumber: query.PassportNumber,
+			Search:           query.Q,
 		},
 	})
 	if err != nil {
@@ -200,7 +228,42 @@ func (r *userRouter) All(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, users)
+	setTotalCountHeader(c, page.Total)
+
+	if page.NextCursor != "" {
+		setNextPageHeaders(c, encodeCursor(page.NextCursor))
+	}
+
+	if query.Format == "envelope" {
+		c.JSON(http.StatusOK, allUserEnvelope{
+			Items:  page.Users,
+			Total:  page.Total,
+			Limit:  page.Limit,
+			Offset: page.Offset,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, page.Users)
+}
+
+// parseUserSort expects validated comma list, empty one gives nil.
+func parseUserSort(sort string) []entities.UserOrder {
+	if sort == "" {
+		return nil
+	}
+
+	fields := strings.Split(sort, ",")
+	orders := make([]entities.UserOrder, 0, len(fields))
+
+	for _, field := range fields {
+		orders = append(orders, entities.UserOrder{
+			By:   strings.TrimPrefix(field, "-"),
+			Desc: strings.HasPrefix(field, "-"),
+		})
+	}
+
+	return orders
 }
 
 type infoUserQuery struct {
@@ -24,6 +24,7 @@ func TestUserCreatePositive(t *testing.T) {
 		Patronymic     string `json:"patronymic"`
 		Address        string `json:"address"`
 		PassportNumber string `json:"passportNumber"`
+		Timezone       string `json:"timezone,omitempty"`
 	}
 
 	testCases := []struct {
@@ -58,6 +59,7 @@ func TestUserCreatePositive(t *testing.T) {
 				Patronymic:     "Jacobson",
 				Address:        "78510 Howard Street",
 				PassportNumber: "8888 666666",
+				Timezone:       "Asia/Vladivostok",
 			},
 		},
 		{
@@ -96,6 +98,7 @@ func TestUserCreateNegative(t *testing.T) {
 		Patronymic     string `json:"patronymic"`
 		Address        string `json:"address"`
 		PassportNumber string `json:"passportNumber"`
+		Timezone       string `json:"timezone,omitempty"`
 	}
 
 	testCases := []struct {
@@ -130,6 +133,17 @@ func TestUserCreateNegative(t *testing.T) {
 				PassportNumber: "3333 333333",
 			},
 		},
+		{
+			key: "unknown timezone",
+			input: user{
+				Surname:        "Bode",
+				Name:           "Rogers",
+				Patronymic:     "Robertovich",
+				Address:        "1123 Ola Brook",
+				PassportNumber: "6666 888888",
+				Timezone:       "Mars/Olympus_Mons",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -157,6 +171,7 @@ func TestUserUpdatePositive(t *testing.T) {
 		Patronymic     string `json:"patronymic"`
 		Address        string `json:"address"`
 		PassportNumber string `json:"passportNumber"`
+		Timezone       string `json:"timezone,omitempty"`
 	}
 
 	testCases := []user{
@@ -183,6 +198,7 @@ func TestUserUpdatePositive(t *testing.T) {
 			Patronymic:     "Wyman-Lockman",
 			Address:        "706 Willms Ranch",
 			PassportNumber: "4324 664656",
+			Timezone:       "America/New_York",
 		},
 	}
 
@@ -211,6 +227,7 @@ func TestUserUpdateNegative(t *testing.T) {
 		Patronymic     string `json:"patronymic"`
 		Address        string `json:"address"`
 		PassportNumber string `json:"passportNumber"`
+		Timezone       string `json:"timezone,omitempty"`
 	}
 
 	testCases := []struct {
@@ -290,6 +307,19 @@ func TestUserUpdateNegative(t *testing.T) {
 			},
 			expectedCode: http.StatusNoContent,
 		},
+		{
+			key: "unknown timezone",
+			input: user{
+				id:             getUserID(postgres, 0),
+				Surname:        "Sporer",
+				Name:           "Lemuel",
+				Patronymic:     "Schultz",
+				Address:        "3042 Nicolas Summit",
+				PassportNumber: "4444 664650",
+				Timezone:       "Local",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -535,6 +565,60 @@ func TestUserGetAllPositive(t *testing.T) {
 				},
 			},
 		},
+		{
+			key: "filter in and not equal",
+			input: input{
+				bySurname: "in:Funk,Rippin,Schulist",
+				byName:    "neq:Katrine",
+			},
+			expected: []user{
+				{
+					Surname:        "Funk",
+					Name:           "Theresia",
+					Patronymic:     "Cummerata-Thompson",
+					Address:        "53636 Gabrielle Mount",
+					PassportNumber: "3333 333333",
+				},
+				{
+					Surname:        "Schulist",
+					Name:           "Kailee",
+					Patronymic:     "Fritsch",
+					Address:        "5303 Church View",
+					PassportNumber: "2515 692797",
+				},
+			},
+		},
+		{
+			key: "filter prefix and suffix",
+			input: input{
+				bySurname: "prefix:Mc",
+				byAddress: "suffix:Pine",
+			},
+			expected: []user{
+				{
+					Surname:        "McCullough",
+					Name:           "Jessie",
+					Patronymic:     "Waelchi",
+					Address:        "8020 Dach Pine",
+					PassportNumber: "3333 444444",
+				},
+			},
+		},
+		{
+			key: "filter not ilike",
+			input: input{
+				bySurname: "notilike:U",
+			},
+			expected: []user{
+				{
+					Surname:        "Rippin",
+					Name:           "Katrine",
+					Patronymic:     "Block",
+					Address:        "985 N Jefferson Street",
+					PassportNumber: "5555 124041",
+				},
+			},
+		},
 		{
 			key: "find by id",
 			input: input{
@@ -578,6 +662,246 @@ func TestUserGetAllPositive(t *testing.T) {
 	}
 }
 
+func TestUserGetAllCursor(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		defer postgres.Close()
+	})
+
+	type user struct {
+		Surname string `json:"surname"`
+	}
+
+	surnames := make([]string, 0)
+	query := url.Values{}
+
+	query.Set("limit", "2")
+	query.Set("surname", "ilike:")
+
+	target := fmt.Sprintf("/v1/users/?%s", query.Encode())
+
+	for target != "" {
+		req, _ := http.NewRequest("GET", target, nil)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		if !assert.Equal(t, http.StatusOK, recorder.Code) {
+			return
+		}
+
+		assert.Equal(t, "5", recorder.Header().Get("X-Total-Count"))
+
+		users := make([]user, 0)
+		json.NewDecoder(recorder.Body).Decode(&users)
+
+		for _, user := range users {
+			surnames = append(surnames, user.Surname)
+		}
+
+		target = ""
+
+		if cursor := recorder.Header().Get("X-Next-Cursor"); cursor != "" {
+			link := recorder.Header().Get("Link")
+
+			assert.Contains(t, link, fmt.Sprintf("cursor=%s", cursor))
+			assert.Contains(t, link, "surname=ilike")
+			assert.True(t, strings.HasSuffix(link, `; rel="next"`))
+
+			query.Set("cursor", cursor)
+			target = fmt.Sprintf("/v1/users/?%s", query.Encode())
+		}
+	}
+
+	assert.Equal(t, []string{"Funk", "Runolfsdottir", "McCullough", "Rippin", "Schulist"}, surnames)
+}
+
+func TestUserGetAllSort(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		defer postgres.Close()
+	})
+
+	type user struct {
+		Surname string `json:"surname"`
+	}
+	type input struct {
+		sort  string
+		limit string
+	}
+
+	testCases := []struct {
+		key      string
+		input    input
+		expected []string
+	}{
+		{
+			key: "by surname",
+			input: input{
+				sort: "surname",
+			},
+			expected: []string{"Funk", "McCullough", "Rippin", "Runolfsdottir", "Schulist"},
+		},
+		{
+			key: "by surname descending with limit",
+			input: input{
+				sort:  "-surname",
+				limit: "2",
+			},
+			expected: []string{"Schulist", "Runolfsdottir"},
+		},
+		{
+			key: "by several columns",
+			input: input{
+				sort: "passportNumber,-name",
+			},
+			expected: []string{"Schulist", "Funk", "McCullough", "Runolfsdottir", "Rippin"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			query := url.Values{}
+
+			query.Set("sort", tc.input.sort)
+			query.Set("limit", tc.input.limit)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/users/?%s", query.Encode()), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+			assert.Empty(t, recorder.Header().Get("X-Next-Cursor"), tc.key)
+
+			users := make([]user, 0)
+			json.NewDecoder(recorder.Body).Decode(&users)
+
+			surnames := make([]string, 0, len(users))
+			for _, user := range users {
+				surnames = append(surnames, user.Surname)
+			}
+
+			assert.Equal(t, tc.expected, surnames, tc.key)
+		})
+	}
+}
+
+func TestUserGetAllSearch(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		defer postgres.Close()
+	})
+
+	type user struct {
+		Surname string  `json:"surname"`
+		Score   float64 `json:"score"`
+	}
+
+	testCases := []struct {
+		key      string
+		q        string
+		expected string
+	}{
+		{
+			key:      "misspelled surname",
+			q:        "Rippn",
+			expected: "Rippin",
+		},
+		{
+			key:      "misspelled address",
+			q:        "Jeferson",
+			expected: "Rippin",
+		},
+		{
+			key:      "name and surname",
+			q:        "Jessie McCulough",
+			expected: "McCullough",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			query := url.Values{}
+
+			query.Set("q", tc.q)
+
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/users/?%s", query.Encode()), nil)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code, tc.key)
+
+			users := make([]user, 0)
+			json.NewDecoder(recorder.Body).Decode(&users)
+
+			if assert.NotEmpty(t, users, tc.key) {
+				assert.Equal(t, tc.expected, users[0].Surname, tc.key)
+				assert.Greater(t, users[0].Score, 0.0, tc.key)
+			}
+		})
+	}
+}
+
+func TestUserGetAllTotal(t *testing.T) {
+	postgres, handler := prepare()
+	t.Cleanup(func() {
+		defer postgres.Close()
+	})
+
+	type user struct {
+		Surname string `json:"surname"`
+	}
+	type envelope struct {
+		Items  []user `json:"items"`
+		Total  int    `json:"total"`
+		Limit  int    `json:"limit"`
+		Offset int    `json:"offset"`
+	}
+
+	t.Run("list", func(t *testing.T) {
+		query := url.Values{}
+
+		query.Set("limit", "2")
+		query.Set("offset", "1")
+
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/users/?%s", query.Encode()), nil)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "5", recorder.Header().Get("X-Total-Count"))
+
+		users := make([]user, 0)
+		json.NewDecoder(recorder.Body).Decode(&users)
+
+		assert.Len(t, users, 2)
+	})
+
+	t.Run("envelope with filter", func(t *testing.T) {
+		query := url.Values{}
+
+		query.Set("surname", "ilike:u")
+		query.Set("limit", "2")
+		query.Set("format", "envelope")
+
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/v1/users/?%s", query.Encode()), nil)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "4", recorder.Header().Get("X-Total-Count"))
+
+		page := envelope{}
+		json.NewDecoder(recorder.Body).Decode(&page)
+
+		assert.Equal(t, envelope{
+			Items:  []user{{Surname: "Funk"}, {Surname: "Runolfsdottir"}},
+			Total:  4,
+			Limit:  2,
+			Offset: 0,
+		}, page)
+	})
+}
+
 func TestUserGetAllNegative(t *testing.T) {
 	postgres, handler := prepare()
 	t.Cleanup(func() {
@@ -593,6 +917,10 @@ func TestUserGetAllNegative(t *testing.T) {
 
 		limit  string
 		offset string
+		cursor string
+		sort   string
+		q      string
+		format string
 	}
 
 	testCases := []struct {
@@ -642,6 +970,27 @@ func TestUserGetAllNegative(t *testing.T) {
 			},
 			expectedCode: http.StatusBadRequest,
 		},
+		{
+			key: "wildcard is escaped",
+			input: input{
+				bySurname: "ilike:_",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "value with colon",
+			input: input{
+				byAddress: "eq:53636:Gabrielle Mount",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "filter without value",
+			input: input{
+				bySurname: "in",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
 		{
 			key: "offset not uint64",
 			input: input{
@@ -649,6 +998,65 @@ func TestUserGetAllNegative(t *testing.T) {
 			},
 			expectedCode: http.StatusBadRequest,
 		},
+		{
+			key: "wrong cursor",
+			input: input{
+				cursor: "twoonethree",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "unknown sort column",
+			input: input{
+				sort: "surname,age",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "empty sort column",
+			input: input{
+				sort: "surname,,name",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "cursor with sort",
+			input: input{
+				sort:   "surname",
+				cursor: "MWVmNDRjZTQtNmFmYi02ZGEwLTllNGUtNmVhM2NiN2RmMzlj",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "nothing is found",
+			input: input{
+				q: "Zzyzx Qwerty",
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			key: "cursor with search",
+			input: input{
+				q:      "Rippin",
+				cursor: "MWVmNDRjZTQtNmFmYi02ZGEwLTllNGUtNmVhM2NiN2RmMzlj",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "unknown format",
+			input: input{
+				format: "xml",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			key: "cursor with offset",
+			input: input{
+				offset: "1",
+				cursor: "MWVmNDRjZTQtNmFmYi02ZGEwLTllNGUtNmVhM2NiN2RmMzlj",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -657,6 +1065,10 @@ func TestUserGetAllNegative(t *testing.T) {
 
 			query.Set("limit", tc.input.limit)
 			query.Set("offset", tc.input.offset)
+			query.Set("cursor", tc.input.cursor)
+			query.Set("sort", tc.input.sort)
+			query.Set("q", tc.input.q)
+			query.Set("format", tc.input.format)
 			query.Set("surname", tc.input.bySurname)
 			query.Set("name", tc.input.byName)
 			query.Set("patronymic", tc.input.byPatronymic)
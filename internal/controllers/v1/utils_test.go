@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/gin-gonic/gin"
 	"github.com/v1adhope/time-tracker/internal/configs"
 	v1 "github.com/v1adhope/time-tracker/internal/controllers/v1"
@@ -11,14 +12,27 @@ import (
 	"github.com/v1adhope/time-tracker/internal/usecases/repositories"
 	"github.com/v1adhope/time-tracker/pkg/logger"
 	"github.com/v1adhope/time-tracker/pkg/postgresql"
+	"github.com/v1adhope/time-tracker/pkg/token"
 )
 
+const testSecret = "test-secret-test-secret-test-secret"
+
 func prepare() (*postgresql.Postgres, *gin.Engine) {
+	return prepareWith(func(cfg *configs.Config) {})
+}
+
+func prepareWith(override func(cfg *configs.Config)) (*postgresql.Postgres, *gin.Engine) {
 	cfg, err := configs.Build("../../../.env")
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	cfg.Gin.AuthEnabled = false
+	cfg.Auth.Algorithm = token.AlgorithmHS256
+	cfg.Auth.Secret = testSecret
+
+	override(cfg)
+
 	appLog := logger.New(cfg.Logger.LogLevel)
 
 	mainCtx := context.Background()
@@ -35,7 +49,12 @@ func prepare() (*postgresql.Postgres, *gin.Engine) {
 
 	seeding(mainCtx, postgres)
 
-	usecases := usecases.New(repos)
+	tokens, err := token.New(&cfg.Auth)
+	if err != nil {
+		log.Fatal("can't build token manager")
+	}
+
+	usecases := usecases.New(repos, cfg.Usecases, tokens)
 
 	if err := v1.RegisterCustomValidations(); err != nil {
 		log.Fatal("can't register custom validations")
@@ -47,6 +66,7 @@ func prepare() (*postgresql.Postgres, *gin.Engine) {
 		Handler:  handler,
 		Usecases: usecases,
 		Log:      appLog,
+		Config:   cfg.Gin,
 	})
 
 	return postgres, handler
@@ -64,19 +84,35 @@ func seeding(ctx context.Context, postgres *postgresql.Postgres) {
 
 	postgres.Pool.Exec(ctx, sql, args...)
 
+	sql, args, _ = postgres.Builder.Insert("projects").
+		Columns("name", "description").
+		Values("Acme website", "Landing page redesign").
+		Values("Internal tooling", "").
+		Values("Mobile app", "iOS and Android clients").
+		ToSql()
+
+	postgres.Pool.Exec(ctx, sql, args...)
+
 	sql, args, _ = postgres.Builder.Insert("tasks").
-		Columns("created_at", "finished_at", "user_id").
-		Values("2024-01-16T09:08:25Z", "2024-01-16T16:10:00Z", getUserID(postgres, 3)).
-		Values("2024-03-11T11:25:00Z", "2024-05-11T09:08:25Z", getUserID(postgres, 3)).
-		Values("2024-04-16T09:08:25Z", "2024-05-16T09:08:25Z", getUserID(postgres, 3)).
-		Values("2024-12-16T09:08:25Z", nil, getUserID(postgres, 3)).
-		Values("2024-08-11T11:25:00Z", nil, getUserID(postgres, 3)).
-		Values("2024-11-16T07:08:25Z", "2024-11-16T09:08:25Z", getUserID(postgres, 2)).
-		Values("2024-05-18T11:00:00Z", "2024-05-20T09:08:25Z", getUserID(postgres, 2)).
-		Values("2024-01-16T07:00:25Z", "2024-01-16T09:08:25Z", getUserID(postgres, 2)).
-		Values("2024-03-16T00:08:25Z", "2024-03-24T00:00:00Z", getUserID(postgres, 2)).
-		Values("2024-12-16T09:08:25Z", nil, getUserID(postgres, 4)).
-		Values("2024-08-11T11:25:00Z", nil, getUserID(postgres, 4)).
+		Columns("created_at", "finished_at", "user_id", "project_id").
+		Values("2024-01-16T09:08:25Z", "2024-01-16T16:10:00Z", getUserID(postgres, 3), nil).
+		Values("2024-03-11T11:25:00Z", "2024-05-11T09:08:25Z", getUserID(postgres, 3), nil).
+		Values("2024-04-16T09:08:25Z", "2024-05-16T09:08:25Z", getUserID(postgres, 3), nil).
+		Values("2024-12-16T09:08:25Z", nil, getUserID(postgres, 3), nil).
+		Values("2024-08-11T11:25:00Z", "2024-12-16T09:08:25Z", getUserID(postgres, 3), nil).
+		Values("2024-11-16T07:08:25Z", "2024-11-16T09:08:25Z", getUserID(postgres, 2), getProjectID(postgres, 0)).
+		Values("2024-05-18T11:00:00Z", "2024-05-20T09:08:25Z", getUserID(postgres, 2), getProjectID(postgres, 0)).
+		Values("2024-01-16T07:00:25Z", "2024-01-16T09:08:25Z", getUserID(postgres, 2), getProjectID(postgres, 1)).
+		Values("2024-03-16T00:08:25Z", "2024-03-24T00:00:00Z", getUserID(postgres, 2), nil).
+		Values("2024-12-16T09:08:25Z", nil, getUserID(postgres, 4), nil).
+		Values("2024-08-11T11:25:00Z", "2024-12-16T09:08:25Z", getUserID(postgres, 4), nil).
+		ToSql()
+
+	postgres.Pool.Exec(ctx, sql, args...)
+
+	sql, args, _ = postgres.Builder.Insert("task_intervals").
+		Columns("task_id", "started_at", "finished_at").
+		Select(squirrel.Select("task_id", "created_at", "finished_at").From("tasks")).
 		ToSql()
 
 	postgres.Pool.Exec(ctx, sql, args...)
@@ -97,3 +133,7 @@ func getUserID(driver *postgresql.Postgres, offset uint64) string {
 func getTaskID(driver *postgresql.Postgres, offset uint64) string {
 	return getID(driver, "tasks", "task_id", offset)
 }
+
+func getProjectID(driver *postgresql.Postgres, offset uint64) string {
+	return getID(driver, "projects", "project_id", offset)
+}
@@ -1,15 +1,18 @@
 package v1
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/v1adhope/time-tracker/internal/entities"
 )
 
 func RegisterCustomValidations() error {
@@ -18,14 +21,26 @@ func RegisterCustomValidations() error {
 		return errors.New("v1: registerCustomValidations: engine not found")
 	}
 
-	if err := v.RegisterValidation("filterstring", filterString); err != nil {
-		return fmt.Errorf("v1: registerCustomValidations: registerValidation: filterString: %w", err)
-	}
-
 	if err := v.RegisterValidation("sorttime", sortTime); err != nil {
 		return fmt.Errorf("v1: registerCustomValidations: registerValidation: sortTime: %w", err)
 	}
 
+	if err := v.RegisterValidation("summarysort", summarySort); err != nil {
+		return fmt.Errorf("v1: registerCustomValidations: registerValidation: summarySort: %w", err)
+	}
+
+	if err := v.RegisterValidation("usersort", userSort); err != nil {
+		return fmt.Errorf("v1: registerCustomValidations: registerValidation: userSort: %w", err)
+	}
+
+	if err := v.RegisterValidation("cursor", cursor); err != nil {
+		return fmt.Errorf("v1: registerCustomValidations: registerValidation: cursor: %w", err)
+	}
+
+	if err := v.RegisterValidation("csvdelimiter", csvDelimiter); err != nil {
+		return fmt.Errorf("v1: registerCustomValidations: registerValidation: csvDelimiter: %w", err)
+	}
+
 	if err := v.RegisterValidation("passport", passport); err != nil {
 		return fmt.Errorf("v1: registerCustomValidations: registerValidation: sortTime: %w", err)
 	}
@@ -37,26 +52,74 @@ func RegisterCustomValidations() error {
 	return nil
 }
 
-func filterString(fl validator.FieldLevel) bool {
+func sortTime(fl validator.FieldLevel) bool {
+	_, err := time.Parse(time.RFC3339, fl.Field().String())
+	if err != nil {
+		return false
+	}
+
+	return true
+}
+
+func summarySort(fl validator.FieldLevel) bool {
 	parts := strings.Split(fl.Field().String(), ":")
 
 	if len(parts) != 2 {
 		return false
 	}
 
-	if parts[0] != "eq" && parts[0] != "ilike" {
+	switch parts[0] {
+	case entities.TaskOrderByCreatedAt, entities.TaskOrderByFinishedAt, entities.TaskOrderBySummaryTime, entities.TaskOrderByID:
+	default:
+		return false
+	}
+
+	if parts[1] != "asc" && parts[1] != "desc" {
 		return false
 	}
 
 	return true
 }
 
-func sortTime(fl validator.FieldLevel) bool {
-	_, err := time.Parse(time.RFC3339, fl.Field().String())
+func userSort(fl validator.FieldLevel) bool {
+	for _, field := range strings.Split(fl.Field().String(), ",") {
+		switch strings.TrimPrefix(field, "-") {
+		case entities.UserOrderByID, entities.UserOrderBySurname, entities.UserOrderByName,
+			entities.UserOrderByPatronymic, entities.UserOrderByAddress, entities.UserOrderByPassportNumber:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func cursor(fl validator.FieldLevel) bool {
+	id, err := base64.RawURLEncoding.DecodeString(fl.Field().String())
 	if err != nil {
 		return false
 	}
 
+	isMatched, err := regexp.Match("^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$", id)
+	if err != nil || !isMatched {
+		return false
+	}
+
+	return true
+}
+
+func csvDelimiter(fl validator.FieldLevel) bool {
+	delimiter := []rune(fl.Field().String())
+
+	if len(delimiter) != 1 {
+		return false
+	}
+
+	switch delimiter[0] {
+	case '"', '\r', '\n', utf8.RuneError:
+		return false
+	}
+
 	return true
 }
 
@@ -0,0 +1,228 @@
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/v1adhope/time-tracker/internal/entities"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	MIMEXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+	xlsxSheetNameLimit = 31
+	xlsxDayDuration    = 24 * time.Hour
+)
+
+var timesheetXLSXHeader = []any{
+	"Date", "Task", "Title", "Project", "Started", "Finished", "Duration",
+}
+
+type timesheetStyles struct {
+	date          int
+	dateTime      int
+	duration      int
+	total         int
+	totalDuration int
+}
+
+// renderTimesheets builds a workbook with a worksheet per user, tasks are followed by daily subtotals
+// and a total row. Durations are stored as fractions of a day so spreadsheet formulas keep working.
+func renderTimesheets(timesheets []entities.TaskTimesheet) (*excelize.File, error) {
+	f := excelize.NewFile()
+
+	styles, err := newTimesheetStyles(f)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, timesheet := range timesheets {
+		sheet := timesheetSheetName(i, timesheet.User)
+
+		if i == 0 {
+			if err := f.SetSheetName(f.GetSheetName(0), sheet); err != nil {
+				return nil, fmt.Errorf("v1: renderTimesheets: setSheetName: %w", err)
+			}
+		} else if _, err := f.NewSheet(sheet); err != nil {
+			return nil, fmt.Errorf("v1: renderTimesheets: newSheet: %w", err)
+		}
+
+		if err := writeTimesheet(f, sheet, styles, timesheet.Tasks); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+func newTimesheetStyles(f *excelize.File) (timesheetStyles, error) {
+	dateFormat := "yyyy-mm-dd"
+	dateTimeFormat := "yyyy-mm-dd hh:mm"
+	durationFormat := "[h]:mm"
+
+	styles := timesheetStyles{}
+
+	for _, item := range []struct {
+		target *int
+		style  *excelize.Style
+	}{
+		{&styles.date, &excelize.Style{CustomNumFmt: &dateFormat}},
+		{&styles.dateTime, &excelize.Style{CustomNumFmt: &dateTimeFormat}},
+		{&styles.duration, &excelize.Style{CustomNumFmt: &durationFormat}},
+		{&styles.total, &excelize.Style{Font: &excelize.Font{Bold: true}, CustomNumFmt: &dateFormat}},
+		{&styles.totalDuration, &excelize.Style{Font: &excelize.Font{Bold: true}, CustomNumFmt: &durationFormat}},
+	} {
+		id, err := f.NewStyle(item.style)
+		if err != nil {
+			return timesheetStyles{}, fmt.Errorf("v1: newTimesheetStyles: newStyle: %w", err)
+		}
+
+		*item.target = id
+	}
+
+	return styles, nil
+}
+
+func writeTimesheet(f *excelize.File, sheet string, styles timesheetStyles, tasks []entities.TaskSummary) error {
+	if err := f.SetSheetRow(sheet, "A1", &timesheetXLSXHeader); err != nil {
+		return fmt.Errorf("v1: writeTimesheet: setSheetRow: header: %w", err)
+	}
+
+	row := 2
+
+	var (
+		day       time.Time
+		dayTotal  time.Duration
+		sumTotal  time.Duration
+		writeDays bool
+	)
+
+	for _, task := range tasks {
+		createdAt, err := time.Parse(time.RFC3339, task.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("v1: writeTimesheet: parse createdAt: %w", err)
+		}
+
+		createdAt = wallClock(createdAt)
+		taskDay := createdAt.Truncate(xlsxDayDuration)
+
+		if writeDays && !taskDay.Equal(day) {
+			if err := writeTimesheetTotal(f, sheet, styles, row, day, "Day total", dayTotal); err != nil {
+				return err
+			}
+
+			row++
+			dayTotal = 0
+		}
+
+		day = taskDay
+		writeDays = true
+
+		// Zero for a task without closed intervals
+		duration := task.SummaryDuration
+
+		dayTotal += duration
+		sumTotal += duration
+
+		values := []any{day, task.ID, task.Title, task.ProjectID, createdAt, nil, durationToDays(duration)}
+
+		if task.FinishedAt != "" {
+			finishedAt, err := time.Parse(time.RFC3339, task.FinishedAt)
+			if err != nil {
+				return fmt.Errorf("v1: writeTimesheet: parse finishedAt: %w", err)
+			}
+
+			values[5] = wallClock(finishedAt)
+		}
+
+		if err := writeTimesheetRow(f, sheet, row, values); err != nil {
+			return err
+		}
+
+		if err := setTimesheetCellStyle(f, sheet, row, "A", "A", styles.date); err != nil {
+			return err
+		}
+
+		if err := setTimesheetCellStyle(f, sheet, row, "E", "F", styles.dateTime); err != nil {
+			return err
+		}
+
+		if err := setTimesheetCellStyle(f, sheet, row, "G", "G", styles.duration); err != nil {
+			return err
+		}
+
+		row++
+	}
+
+	if writeDays {
+		if err := writeTimesheetTotal(f, sheet, styles, row, day, "Day total", dayTotal); err != nil {
+			return err
+		}
+
+		row++
+	}
+
+	if err := writeTimesheetTotal(f, sheet, styles, row, nil, "Total", sumTotal); err != nil {
+		return err
+	}
+
+	if err := f.SetColWidth(sheet, "A", "G", 20); err != nil {
+		return fmt.Errorf("v1: writeTimesheet: setColWidth: %w", err)
+	}
+
+	return nil
+}
+
+func writeTimesheetTotal(f *excelize.File, sheet string, styles timesheetStyles, row int, day any, title string, total time.Duration) error {
+	if err := writeTimesheetRow(f, sheet, row, []any{day, nil, title, nil, nil, nil, durationToDays(total)}); err != nil {
+		return err
+	}
+
+	if err := setTimesheetCellStyle(f, sheet, row, "A", "F", styles.total); err != nil {
+		return err
+	}
+
+	return setTimesheetCellStyle(f, sheet, row, "G", "G", styles.totalDuration)
+}
+
+func writeTimesheetRow(f *excelize.File, sheet string, row int, values []any) error {
+	cell, err := excelize.CoordinatesToCellName(1, row)
+	if err != nil {
+		return fmt.Errorf("v1: writeTimesheetRow: coordinatesToCellName: %w", err)
+	}
+
+	if err := f.SetSheetRow(sheet, cell, &values); err != nil {
+		return fmt.Errorf("v1: writeTimesheetRow: setSheetRow: %w", err)
+	}
+
+	return nil
+}
+
+func setTimesheetCellStyle(f *excelize.File, sheet string, row int, fromCol, toCol string, style int) error {
+	if err := f.SetCellStyle(sheet, fmt.Sprintf("%s%d", fromCol, row), fmt.Sprintf("%s%d", toCol, row), style); err != nil {
+		return fmt.Errorf("v1: setTimesheetCellStyle: setCellStyle: %w", err)
+	}
+
+	return nil
+}
+
+// wallClock keeps local date and time of report timestamps, spreadsheets have no time zones.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+func durationToDays(d time.Duration) float64 {
+	return float64(d) / float64(xlsxDayDuration)
+}
+
+// timesheetSheetName keeps names unique by the position of the user and fits them into the sheet name limit.
+func timesheetSheetName(i int, user entities.User) string {
+	name := []rune(fmt.Sprintf("%d %s %s", i+1, user.Surname, user.Name))
+
+	if len(name) > xlsxSheetNameLimit {
+		name = name[:xlsxSheetNameLimit]
+	}
+
+	return string(name)
+}
@@ -0,0 +1,20 @@
+package entities
+
+type Credentials struct {
+	UserID       string
+	Login        string
+	Password     string
+	PasswordHash string
+	IsAdmin      bool
+	Version      int
+}
+
+type Token struct {
+	AccessToken string `json:"accessToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJzdWIiOiIxZWY0ZjE4OS03YjJhLTY3NDAtYTYwOS0zNzBlZDYzYTlmYzcifQ.signature"`
+	TokenType   string `json:"tokenType" example:"Bearer"`
+	ExpiresAt   string `json:"expiresAt" example:"2024-12-16T10:08:25Z"`
+}
+
+type CalendarToken struct {
+	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJzdWIiOiIxZWY0ZjE4OS03YjJhLTY3NDAtYTYwOS0zNzBlZDYzYTlmYzcifQ.signature"`
+}
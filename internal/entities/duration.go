@@ -0,0 +1,17 @@
+package entities
+
+const (
+	DurationFormatHuman   = "human"
+	DurationFormatSeconds = "seconds"
+	DurationFormatISO8601 = "iso8601"
+	DurationFormatAll     = "all"
+
+	DurationRoundingNone        = "none"
+	DurationRoundingMinute      = "minute"
+	DurationRoundingQuarterHour = "quarter-hour"
+)
+
+type DurationRepresentation struct {
+	Format   string
+	Rounding string
+}
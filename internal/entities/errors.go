@@ -9,4 +9,26 @@ var (
 
 	ErrorTaskDoesNotExist      = errors.New("task doesn't exist")
 	ErrorNoAnyTasksForThisUser = errors.New("no any tasks for this user")
+	ErrorTaskAlreadyFinished   = errors.New("task has already finished")
+	ErrorTaskHasAlreadyPaused  = errors.New("task has already paused")
+	ErrorTaskIsNotPaused       = errors.New("task isn't paused")
+	ErrorUserHasRunningTask    = errors.New("user has already running task")
+	ErrorTaskInvalidTimeRange  = errors.New("task should finish after it was created")
+	ErrorTaskInFuture          = errors.New("task can't be in the future")
+	ErrorTaskIsRunning         = errors.New("task is running, end it before")
+	ErrorTaskOverlaps          = errors.New("task overlaps another task of this user")
+	ErrorNoAnyTaskOverlaps     = errors.New("no any overlapping tasks for this user")
+	ErrorTaskCursorWithoutID   = errors.New("cursor can be used only with sort by id")
+	ErrorTaskDurationFormatCSV = errors.New("csv has human summary time only, durationFormat can't be used with it")
+
+	ErrorProjectHasAlreadyExistWithThatName = errors.New("project has already exist with that name")
+	ErrorProjectsDoesNotExist               = errors.New("project(s) doesn't exist")
+
+	ErrorFilterInvalid   = errors.New("filter should be operation:value with known operation")
+	ErrorNothingToUpdate = errors.New("at least one field should be set to update")
+
+	ErrorInvalidCredentials                      = errors.New("login or password is wrong")
+	ErrorUnauthorized                            = errors.New("bearer token is missing or invalid")
+	ErrorForbidden                               = errors.New("not allowed to access data of another user")
+	ErrorCredentialsHasAlreadyExistWithThatLogin = errors.New("credentials has already exist with that login")
 )
@@ -0,0 +1,61 @@
+package entities
+
+import "strings"
+
+const (
+	FilterOperationEq       = "eq"
+	FilterOperationNeq      = "neq"
+	FilterOperationIn       = "in"
+	FilterOperationPrefix   = "prefix"
+	FilterOperationSuffix   = "suffix"
+	FilterOperationIlike    = "ilike"
+	FilterOperationNotIlike = "notilike"
+)
+
+// Filter is a parsed operation:value string, the zero value filters nothing.
+// Values are set for in operation only.
+type Filter struct {
+	Operation string
+	Value     string
+	Values    []string
+}
+
+// UnmarshalParam binds a query parameter right into the filter, so it's parsed once. Empty one filters nothing.
+func (f *Filter) UnmarshalParam(param string) error {
+	if param == "" {
+		*f = Filter{}
+		return nil
+	}
+
+	filter, err := ParseFilter(param)
+	if err != nil {
+		return err
+	}
+
+	*f = filter
+
+	return nil
+}
+
+// ParseFilter splits on the first colon only, so values may contain colons.
+func ParseFilter(target string) (Filter, error) {
+	operation, value, ok := strings.Cut(target, ":")
+	if !ok {
+		return Filter{}, ErrorFilterInvalid
+	}
+
+	filter := Filter{
+		Operation: operation,
+		Value:     value,
+	}
+
+	switch operation {
+	case FilterOperationEq, FilterOperationNeq, FilterOperationPrefix, FilterOperationSuffix, FilterOperationIlike, FilterOperationNotIlike:
+	case FilterOperationIn:
+		filter.Values = strings.Split(value, ",")
+	default:
+		return Filter{}, ErrorFilterInvalid
+	}
+
+	return filter, nil
+}
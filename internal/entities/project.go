@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+type Project struct {
+	ID          string `json:"id" example:"1ef5a3c1-2b4e-6f10-9c3d-5b7e1a2f4c6d"`
+	Name        string `json:"name" example:"Acme website"`
+	Description string `json:"description,omitempty" example:"Landing page redesign"`
+}
+
+type ProjectPagination struct {
+	Limit  string
+	Offset string
+}
+
+type ProjectSummary struct {
+	ProjectID       string        `json:"projectId,omitempty"`
+	ProjectName     string        `json:"projectName,omitempty"`
+	TasksCount      int           `json:"tasksCount"`
+	SummaryTime     string        `json:"summaryTime,omitempty"`
+	SummaryDuration time.Duration `json:"-"`
+}
@@ -1,20 +1,107 @@
 package entities
 
+import "time"
+
 type Task struct {
-	ID         string `json:"id" example:"1ef4e803-1af7-6a50-85b2-77ed6f34a8cf"`
-	CreatedAt  string `json:"createdAt" example:"2024-01-16 09:08:25"`
-	FinishedAt string `json:"finishedAt,omitempty" example:"2024-01-16 16:10:00"`
-	UserID     string `json:"userId" example:"1ef4e803-1aed-62e0-8d59-c8cfd7561759"`
+	ID          string   `json:"id" example:"1ef4e803-1af7-6a50-85b2-77ed6f34a8cf"`
+	CreatedAt   string   `json:"createdAt" example:"2024-01-16T09:08:25Z"`
+	FinishedAt  string   `json:"finishedAt,omitempty" example:"2024-01-16T16:10:00Z"`
+	UserID      string   `json:"userId" example:"1ef4e803-1aed-62e0-8d59-c8cfd7561759"`
+	Title       string   `json:"title" example:"Code review"`
+	Description string   `json:"description,omitempty" example:"Review pending merge requests"`
+	Labels      []string `json:"labels" example:"review,backend"`
+	ProjectID   string   `json:"projectId,omitempty" example:"1ef5a3c1-2b4e-6f10-9c3d-5b7e1a2f4c6d"`
 }
 
 type TaskSummary struct {
-	ID          string `json:"id"`
-	CreatedAt   string `json:"createdAt"`
-	FinishedAt  string `json:"finishedAt,omitempty"`
-	SummaryTime string `json:"summaryTime,omitempty"`
+	ID                 string        `json:"id"`
+	CreatedAt          string        `json:"createdAt"`
+	FinishedAt         string        `json:"finishedAt,omitempty"`
+	SummaryTime        string        `json:"summaryTime,omitempty"`
+	SummaryTimeSeconds *int64        `json:"summaryTimeSeconds,omitempty"`
+	SummaryTimeISO     string        `json:"summaryTimeIso,omitempty"`
+	SummaryDuration    time.Duration `json:"-"`
+	HasDuration        bool          `json:"-"`
+	Title              string        `json:"title"`
+	Description        string        `json:"description,omitempty"`
+	Labels             []string      `json:"labels"`
+	ProjectID          string        `json:"projectId,omitempty"`
+}
+
+// TaskPagination is empty when the whole report is requested. Ordered by task id pages can go by cursor,
+// the id of the last task on a full page is the cursor of the next one.
+type TaskPagination struct {
+	Limit  string
+	Offset string
+	Cursor string
+}
+
+type TaskSummaryPage struct {
+	Tasks      []TaskSummary
+	Total      int
+	NextCursor string
+}
+
+type TaskSummaryRecord struct {
+	User                 User
+	Task                 TaskSummary
+	TotalTasksCount      int
+	TotalSummaryTime     string
+	TotalSummaryDuration time.Duration
+}
+
+type TaskTimesheet struct {
+	User  User
+	Tasks []TaskSummary
+}
+
+type TaskPeriodSummary struct {
+	Period          string        `json:"period" example:"2024-01-15T00:00:00Z"`
+	TasksCount      int           `json:"tasksCount" example:"3"`
+	SummaryTime     string        `json:"summaryTime,omitempty" example:"12h30m"`
+	SummaryDuration time.Duration `json:"-"`
+}
+
+type TaskPeriodReport struct {
+	Periods         []TaskPeriodSummary `json:"periods"`
+	TasksCount      int                 `json:"tasksCount" example:"7"`
+	SummaryTime     string              `json:"summaryTime,omitempty" example:"30h15m"`
+	SummaryDuration time.Duration       `json:"-"`
+}
+
+type TaskOverlap struct {
+	TaskID          string        `json:"taskId"`
+	OverlapTaskID   string        `json:"overlapTaskId"`
+	StartedAt       string        `json:"startedAt"`
+	FinishedAt      string        `json:"finishedAt,omitempty"`
+	OverlapTime     string        `json:"overlapTime,omitempty"`
+	OverlapDuration time.Duration `json:"-"`
+}
+
+const (
+	TaskOrderByCreatedAt   = "createdAt"
+	TaskOrderByFinishedAt  = "finishedAt"
+	TaskOrderBySummaryTime = "summaryTime"
+	TaskOrderByID          = "id"
+)
+
+const (
+	TaskRangeModeContained = "contained"
+	TaskRangeModeOverlap   = "overlap"
+)
+
+// TaskOrder is an order of summary time report, the zero value is summary time descending.
+type TaskOrder struct {
+	By   string
+	Desc bool
 }
 
 type TaskSort struct {
 	StartTime string
 	EndTime   string
+	ProjectID string
+	Timezone  string
+	Order     TaskOrder
+	// Mode is how tasks are matched against the range, contained by default.
+	Mode string
 }
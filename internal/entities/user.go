@@ -1,5 +1,7 @@
 package entities
 
+import "time"
+
 type User struct {
 	ID             string `json:"id" example:"1ef4f189-7b2a-6740-a609-370ed63a9fc7"`
 	Surname        string `json:"surname" example:"Funk"`
@@ -7,23 +9,72 @@ type User struct {
 	Patronymic     string `json:"patronymic" example:"Cummerata-Thompson"`
 	Address        string `json:"address" example:"53636 Gabrielle Mount"`
 	PassportNumber string `json:"passportNumber" example:"3333 333333"`
+	Timezone       string `json:"timezone" example:"Asia/Vladivostok"`
+	// Score is relevance of the user to the search query.
+	Score float64 `json:"score,omitempty" example:"0.8"`
 }
 
+// UserPagination pages go in order of user id without sort and search, Cursor is an id of the last user of the previous page.
 type UserPagination struct {
 	Limit  string
 	Offset string
+	Cursor string
+}
+
+// UserPage Total counts all users matching the filter, Limit and Offset are the ones the page was taken with.
+type UserPage struct {
+	Users      []User
+	Total      int
+	Limit      uint64
+	Offset     uint64
+	NextCursor string
 }
 
 type UserFilter struct {
 	ByID             string
-	BySurname        string
-	ByName           string
-	ByPatronymic     string
-	ByAddress        string
-	ByPassportNumber string
+	BySurname        Filter
+	ByName           Filter
+	ByPatronymic     Filter
+	ByAddress        Filter
+	ByPassportNumber Filter
+	// Search is a fuzzy query over surname, name, patronymic and address.
+	Search string
+}
+
+const (
+	UserOrderByID             = "id"
+	UserOrderBySurname        = "surname"
+	UserOrderByName           = "name"
+	UserOrderByPatronymic     = "patronymic"
+	UserOrderByAddress        = "address"
+	UserOrderByPassportNumber = "passportNumber"
+)
+
+type UserOrder struct {
+	By   string
+	Desc bool
 }
 
 type UserRepresentation struct {
 	Pagination UserPagination
 	Filter     UserFilter
+	// Sort is applied before user id, which keeps the order stable.
+	Sort []UserOrder
+}
+
+type UserSummary struct {
+	UserID          string        `json:"userId" example:"1ef4f189-7b2a-6740-a609-370ed63a9fc7"`
+	Surname         string        `json:"surname" example:"Funk"`
+	Name            string        `json:"name" example:"Theresia"`
+	TasksCount      int           `json:"tasksCount" example:"4"`
+	SummaryTime     string        `json:"summaryTime,omitempty" example:"242h8m"`
+	SummaryDuration time.Duration `json:"-"`
+	Timezone        string        `json:"-"`
+}
+
+type UserSummaryRepresentation struct {
+	Pagination UserPagination
+	Filter     UserFilter
+	Sort       TaskSort
+	Order      string
 }
@@ -0,0 +1,222 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/v1adhope/time-tracker/internal/entities"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const tokenTypeBearer = "Bearer"
+
+// dummyPasswordHash is compared on login with unknown login, the login is rejected whatever the result.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+type userIDKey struct{}
+
+// WithUserID returns a copy of ctx carrying ID of the authenticated user.
+func WithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, id)
+}
+
+// UserIDFromContext reports ID of the authenticated user, false when the request is anonymous.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(userIDKey{}).(string)
+
+	return id, ok
+}
+
+type AuthUsecase struct {
+	credentialRepo CredentialRepo
+	userRepo       UserRepo
+	tokens         TokenManager
+}
+
+func NewAuth(cr CredentialRepo, ur UserRepo, tm TokenManager) *AuthUsecase {
+	return &AuthUsecase{cr, ur, tm}
+}
+
+func (u *AuthUsecase) Login(ctx context.Context, credentials entities.Credentials) (entities.Token, error) {
+	target, err := u.credentialRepo.GetByLogin(ctx, credentials.Login)
+	if err != nil && !errors.Is(err, entities.ErrorInvalidCredentials) {
+		return entities.Token{}, err
+	}
+
+	// Unknown login is compared as well, so it takes as long as a wrong password
+	knownLogin := err == nil
+
+	hash := dummyPasswordHash
+	if knownLogin {
+		hash = []byte(target.PasswordHash)
+	}
+
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(credentials.Password)); err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return entities.Token{}, entities.ErrorInvalidCredentials
+		}
+
+		return entities.Token{}, fmt.Errorf("usecases: auth: login: compare: %w", err)
+	}
+
+	if !knownLogin {
+		return entities.Token{}, entities.ErrorInvalidCredentials
+	}
+
+	signed, expiresAt, err := u.tokens.Issue(target.UserID, target.Version)
+	if err != nil {
+		return entities.Token{}, err
+	}
+
+	return entities.Token{
+		AccessToken: signed,
+		TokenType:   tokenTypeBearer,
+		ExpiresAt:   expiresAt.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// Authenticate returns subject of the token, any verification failure is reported as unauthorized.
+// The token is valid only while the subject has credentials of the same version it was issued for.
+func (u *AuthUsecase) Authenticate(ctx context.Context, token string) (string, error) {
+	id, version, err := u.tokens.Parse(token)
+	if err != nil {
+		return "", entities.ErrorUnauthorized
+	}
+
+	if err := u.checkVersion(ctx, id, version); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// IssueCalendarToken signs a calendar feed token of the authenticated user, it is revoked with the credentials.
+func (u *AuthUsecase) IssueCalendarToken(ctx context.Context) (entities.CalendarToken, error) {
+	id, ok := UserIDFromContext(ctx)
+	if !ok {
+		return entities.CalendarToken{}, entities.ErrorUnauthorized
+	}
+
+	version, err := u.credentialRepo.GetVersion(ctx, id)
+	if err != nil {
+		return entities.CalendarToken{}, err
+	}
+
+	signed, err := u.tokens.IssueFeed(id, version)
+	if err != nil {
+		return entities.CalendarToken{}, err
+	}
+
+	return entities.CalendarToken{
+		Token: signed,
+	}, nil
+}
+
+// AuthenticateCalendar is Authenticate for calendar feed tokens.
+func (u *AuthUsecase) AuthenticateCalendar(ctx context.Context, token string) (string, error) {
+	id, version, err := u.tokens.ParseFeed(token)
+	if err != nil {
+		return "", entities.ErrorUnauthorized
+	}
+
+	if err := u.checkVersion(ctx, id, version); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (u *AuthUsecase) checkVersion(ctx context.Context, userID string, version int) error {
+	currentVersion, err := u.credentialRepo.GetVersion(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if currentVersion != version {
+		return entities.ErrorUnauthorized
+	}
+
+	return nil
+}
+
+// SetCredentials lets the authenticated user replace only own credentials, admin may replace anyone's.
+// Without authenticated user in ctx (auth is disabled) the credentials are set as is.
+func (u *AuthUsecase) SetCredentials(ctx context.Context, credentials entities.Credentials) error {
+	if callerID, ok := UserIDFromContext(ctx); ok && callerID != credentials.UserID {
+		isAdmin, err := u.credentialRepo.IsAdmin(ctx, callerID)
+		if err != nil {
+			return err
+		}
+
+		if !isAdmin {
+			return entities.ErrorForbidden
+		}
+	}
+
+	hash, err := hashPassword(credentials.Password)
+	if err != nil {
+		return fmt.Errorf("usecases: auth: setCredentials: %w", err)
+	}
+
+	credentials.PasswordHash = hash
+
+	if err := u.credentialRepo.Set(ctx, credentials); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Bootstrap provisions the first admin, so a token can be obtained while the whole API is closed.
+// The admin user is found by passport number and created when missing. Without admin login it only
+// makes sure an admin was provisioned before, otherwise nobody could ever get a token.
+func (u *AuthUsecase) Bootstrap(ctx context.Context, passportNumber string, credentials entities.Credentials) error {
+	if credentials.Login == "" {
+		hasAdmin, err := u.credentialRepo.HasAdmin(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !hasAdmin {
+			return errors.New("usecases: auth: bootstrap: there is no admin yet, set admin login, password and passport")
+		}
+
+		return nil
+	}
+
+	if credentials.Password == "" || passportNumber == "" {
+		return errors.New("usecases: auth: bootstrap: admin password and passport should be set with admin login")
+	}
+
+	if _, err := u.userRepo.Create(ctx, entities.User{
+		Surname:        "admin",
+		Name:           "admin",
+		PassportNumber: passportNumber,
+	}); err != nil && !errors.Is(err, entities.ErrorUserHasAlreadyExistWithThatPassport) {
+		return err
+	}
+
+	hash, err := hashPassword(credentials.Password)
+	if err != nil {
+		return fmt.Errorf("usecases: auth: bootstrap: %w", err)
+	}
+
+	credentials.PasswordHash = hash
+
+	if err := u.credentialRepo.SetAdmin(ctx, passportNumber, credentials); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("hash: %w", err)
+	}
+
+	return string(hash), nil
+}
@@ -2,14 +2,30 @@ package usecases
 
 import "github.com/v1adhope/time-tracker/internal/usecases/repositories"
 
+const (
+	TaskRunningPolicyReject = "reject"
+	TaskRunningPolicyStop   = "stop"
+)
+
+type Config struct {
+	TaskRunningPolicy string `koanf:"APP_TASK_RUNNING_POLICY"`
+	AuthAdminPassport string `koanf:"APP_AUTH_ADMIN_PASSPORT"`
+	AuthAdminLogin    string `koanf:"APP_AUTH_ADMIN_LOGIN"`
+	AuthAdminPassword string `koanf:"APP_AUTH_ADMIN_PASSWORD"`
+}
+
 type Usecases struct {
-	User *UserUsecase
-	Task *TaskUsecase
+	User    *UserUsecase
+	Task    *TaskUsecase
+	Project *ProjectUsecase
+	Auth    *AuthUsecase
 }
 
-func New(repos *repositories.Repos) *Usecases {
+func New(repos *repositories.Repos, cfg Config, tokens TokenManager) *Usecases {
 	return &Usecases{
-		User: NewUser(repos.User),
-		Task: NewTask(repos.Task),
+		User:    NewUser(repos.User),
+		Task:    NewTask(repos.Task, repos.User, cfg.TaskRunningPolicy),
+		Project: NewProject(repos.Project),
+		Auth:    NewAuth(repos.Credential, repos.User, tokens),
 	}
 }
@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/v1adhope/time-tracker/internal/entities"
+)
+
+// setTaskSummaryTime rewrites summary time of the task by the requested rounding and adds
+// machine-readable representations, summaryTime is kept human-readable for compatibility.
+// A task without any closed interval has no duration at all, not zero one, so it's left as is.
+func setTaskSummaryTime(task *entities.TaskSummary, representation entities.DurationRepresentation) {
+	if !task.HasDuration {
+		return
+	}
+
+	d := roundDuration(task.SummaryDuration, representation.Rounding)
+
+	task.SummaryTime = formatHumanDuration(d)
+
+	if representation.Format == entities.DurationFormatSeconds || representation.Format == entities.DurationFormatAll {
+		seconds := int64(d / time.Second)
+		task.SummaryTimeSeconds = &seconds
+	}
+
+	if representation.Format == entities.DurationFormatISO8601 || representation.Format == entities.DurationFormatAll {
+		task.SummaryTimeISO = formatISO8601Duration(d)
+	}
+}
+
+// formatSummaryTime is the human summary time of reports, rounded to minutes by default.
+func formatSummaryTime(d time.Duration, rounding string) string {
+	return formatHumanDuration(roundDuration(d, rounding))
+}
+
+func roundDuration(d time.Duration, rounding string) time.Duration {
+	switch rounding {
+	case entities.DurationRoundingNone:
+		return d
+	case entities.DurationRoundingQuarterHour:
+		return d.Round(15 * time.Minute)
+	default:
+		return d.Round(time.Minute)
+	}
+}
+
+// formatHumanDuration drops zero seconds only after minutes, so 1h10s isn't cut.
+func formatHumanDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+
+	value := d.String()
+
+	if strings.HasSuffix(value, "m0s") {
+		return strings.TrimSuffix(value, "0s")
+	}
+
+	return value
+}
+
+// formatISO8601Duration formats duration without days designator, because days aren't always 24 hours.
+func formatISO8601Duration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString("PT")
+
+	if hours := d / time.Hour; hours != 0 {
+		builder.WriteString(strconv.FormatInt(int64(hours), 10))
+		builder.WriteString("H")
+		d -= hours * time.Hour
+	}
+
+	if minutes := d / time.Minute; minutes != 0 {
+		builder.WriteString(strconv.FormatInt(int64(minutes), 10))
+		builder.WriteString("M")
+		d -= minutes * time.Minute
+	}
+
+	if d != 0 {
+		builder.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64))
+		builder.WriteString("S")
+	}
+
+	return builder.String()
+}
@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"time"
 
 	"github.com/v1adhope/time-tracker/internal/entities"
 )
@@ -10,7 +11,7 @@ type User interface {
 	Create(ctx context.Context, user entities.User) (string, error)
 	Delete(ctx context.Context, id string) error
 	Update(ctx context.Context, user entities.User) error
-	GetAll(ctx context.Context, representation entities.UserRepresentation) ([]entities.User, error)
+	GetAll(ctx context.Context, representation entities.UserRepresentation) (entities.UserPage, error)
 	Get(ctx context.Context, passportNumber string) (entities.User, error)
 }
 
@@ -18,18 +19,84 @@ type UserRepo interface {
 	Create(ctx context.Context, user entities.User) (string, error)
 	Delete(ctx context.Context, id string) error
 	Update(ctx context.Context, user entities.User) error
-	GetAll(ctx context.Context, representation entities.UserRepresentation) ([]entities.User, error)
+	GetAll(ctx context.Context, representation entities.UserRepresentation) (entities.UserPage, error)
 	Get(ctx context.Context, passportNumber string) (entities.User, error)
 }
 
 type Task interface {
-	Start(ctx context.Context, userID string) error
+	Start(ctx context.Context, task entities.Task) error
+	Create(ctx context.Context, task entities.Task) (string, error)
 	End(ctx context.Context, id string) (string, error)
-	GetReportSummaryTime(ctx context.Context, userID string, sort entities.TaskSort) ([]entities.TaskSummary, error)
+	Pause(ctx context.Context, id string) (string, error)
+	Resume(ctx context.Context, id string) (string, error)
+	Update(ctx context.Context, task entities.Task) error
+	Delete(ctx context.Context, id string) error
+	Get(ctx context.Context, id string) (entities.Task, error)
+	GetOverlaps(ctx context.Context, userID string) ([]entities.TaskOverlap, error)
+	GetReportSummaryTime(ctx context.Context, userID string, sort entities.TaskSort, pagination entities.TaskPagination, duration entities.DurationRepresentation) (entities.TaskSummaryPage, error)
+	GetReportSummaryTimeByProject(ctx context.Context, userID string, sort entities.TaskSort) ([]entities.ProjectSummary, error)
+	GetReportSummaryTimeByPeriod(ctx context.Context, userID, groupBy string, sort entities.TaskSort) (entities.TaskPeriodReport, error)
+	GetReportSummaryTimeByUsers(ctx context.Context, representation entities.UserSummaryRepresentation) ([]entities.UserSummary, error)
+	GetReportTimesheets(ctx context.Context, representation entities.UserSummaryRepresentation) ([]entities.TaskTimesheet, error)
+	GetReportTimesheet(ctx context.Context, userID string, sort entities.TaskSort) (entities.TaskTimesheet, error)
+	ExportReportSummaryTime(ctx context.Context, userID string, sort entities.TaskSort, duration entities.DurationRepresentation, fn func(record entities.TaskSummaryRecord) error) error
 }
 
 type TaskRepo interface {
-	Create(ctx context.Context, userID string) (string, error)
+	Create(ctx context.Context, task entities.Task) (string, error)
+	CreateStoppingRunning(ctx context.Context, task entities.Task) (string, error)
+	CreateFinished(ctx context.Context, task entities.Task) (string, error)
 	SetFinishedAt(ctx context.Context, id string) (string, error)
-	GetReportSummaryTime(ctx context.Context, userID string, sort entities.TaskSort) ([]entities.TaskSummary, error)
+	Pause(ctx context.Context, id string) (string, error)
+	Resume(ctx context.Context, id string) (string, error)
+	Update(ctx context.Context, task entities.Task) error
+	Delete(ctx context.Context, id string) error
+	Get(ctx context.Context, id string) (entities.Task, error)
+	GetOverlaps(ctx context.Context, userID string) ([]entities.TaskOverlap, error)
+	GetReportSummaryTime(ctx context.Context, userID string, sort entities.TaskSort, pagination entities.TaskPagination) (entities.TaskSummaryPage, error)
+	GetReportSummaryTimeByProject(ctx context.Context, userID string, sort entities.TaskSort) ([]entities.ProjectSummary, error)
+	GetReportSummaryTimeByPeriod(ctx context.Context, userID, groupBy string, sort entities.TaskSort) (entities.TaskPeriodReport, error)
+	GetReportSummaryTimeByUsers(ctx context.Context, representation entities.UserSummaryRepresentation) ([]entities.UserSummary, error)
+	EachReportSummaryTime(ctx context.Context, userID string, sort entities.TaskSort, fn func(record entities.TaskSummaryRecord) error) error
+}
+
+type Project interface {
+	Create(ctx context.Context, project entities.Project) (string, error)
+	Delete(ctx context.Context, id string) error
+	Update(ctx context.Context, project entities.Project) error
+	GetAll(ctx context.Context, pagination entities.ProjectPagination) ([]entities.Project, error)
+	Get(ctx context.Context, id string) (entities.Project, error)
+}
+
+type ProjectRepo interface {
+	Create(ctx context.Context, project entities.Project) (string, error)
+	Delete(ctx context.Context, id string) error
+	Update(ctx context.Context, project entities.Project) error
+	GetAll(ctx context.Context, pagination entities.ProjectPagination) ([]entities.Project, error)
+	Get(ctx context.Context, id string) (entities.Project, error)
+}
+
+type Auth interface {
+	Login(ctx context.Context, credentials entities.Credentials) (entities.Token, error)
+	Authenticate(ctx context.Context, token string) (string, error)
+	IssueCalendarToken(ctx context.Context) (entities.CalendarToken, error)
+	AuthenticateCalendar(ctx context.Context, token string) (string, error)
+	SetCredentials(ctx context.Context, credentials entities.Credentials) error
+	Bootstrap(ctx context.Context, passportNumber string, credentials entities.Credentials) error
+}
+
+type CredentialRepo interface {
+	Set(ctx context.Context, credentials entities.Credentials) error
+	GetByLogin(ctx context.Context, login string) (entities.Credentials, error)
+	GetVersion(ctx context.Context, userID string) (int, error)
+	SetAdmin(ctx context.Context, passportNumber string, credentials entities.Credentials) error
+	IsAdmin(ctx context.Context, userID string) (bool, error)
+	HasAdmin(ctx context.Context) (bool, error)
+}
+
+type TokenManager interface {
+	Issue(subject string, version int) (string, time.Time, error)
+	Parse(token string) (string, int, error)
+	IssueFeed(subject string, version int) (string, error)
+	ParseFeed(token string) (string, int, error)
 }
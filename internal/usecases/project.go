@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/v1adhope/time-tracker/internal/entities"
+)
+
+type ProjectUsecase struct {
+	projectRepo ProjectRepo
+}
+
+func NewProject(pr ProjectRepo) *ProjectUsecase {
+	return &ProjectUsecase{pr}
+}
+
+func (u *ProjectUsecase) Create(ctx context.Context, project entities.Project) (string, error) {
+	id, err := u.projectRepo.Create(ctx, project)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (u *ProjectUsecase) Delete(ctx context.Context, id string) error {
+	if err := u.projectRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *ProjectUsecase) Update(ctx context.Context, project entities.Project) error {
+	if project.Name == "" && project.Description == "" {
+		return entities.ErrorNothingToUpdate
+	}
+
+	if err := u.projectRepo.Update(ctx, project); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *ProjectUsecase) GetAll(ctx context.Context, pagination entities.ProjectPagination) ([]entities.Project, error) {
+	projects, err := u.projectRepo.GetAll(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (u *ProjectUsecase) Get(ctx context.Context, id string) (entities.Project, error) {
+	project, err := u.projectRepo.Get(ctx, id)
+	if err != nil {
+		return entities.Project{}, err
+	}
+
+	return project, nil
+}
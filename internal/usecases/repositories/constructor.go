@@ -3,13 +3,17 @@ package repositories
 import "github.com/v1adhope/time-tracker/pkg/postgresql"
 
 type Repos struct {
-	User *UserRepo
-	Task *TaskRepo
+	User       *UserRepo
+	Task       *TaskRepo
+	Project    *ProjectRepo
+	Credential *CredentialRepo
 }
 
 func New(driver *postgresql.Postgres) *Repos {
 	return &Repos{
-		User: NewUser(driver),
-		Task: NewTask(driver),
+		User:       NewUser(driver),
+		Task:       NewTask(driver),
+		Project:    NewProject(driver),
+		Credential: NewCredential(driver),
 	}
 }
@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/v1adhope/time-tracker/internal/entities"
+	"github.com/v1adhope/time-tracker/pkg/postgresql"
+)
+
+type CredentialRepo struct {
+	Driver *postgresql.Postgres
+}
+
+func NewCredential(d *postgresql.Postgres) *CredentialRepo {
+	return &CredentialRepo{d}
+}
+
+// Set replaces credentials of the user if there are any, bumping their version revokes issued tokens.
+func (r *CredentialRepo) Set(ctx context.Context, credentials entities.Credentials) error {
+	sql, args, err := r.Driver.Builder.Insert("credentials").
+		Columns("user_id", "login", "password_hash").
+		Values(credentials.UserID, credentials.Login, credentials.PasswordHash).
+		Suffix("on conflict (user_id) do update set login = excluded.login, password_hash = excluded.password_hash, version = credentials.version + 1").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repositories: credential: set: tosql: %w", err)
+	}
+
+	if _, err := r.Driver.Pool.Exec(ctx, sql, args...); err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "uq_credentials_login" {
+			return entities.ErrorCredentialsHasAlreadyExistWithThatLogin
+		}
+
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "fk_credentials_users_user_id" {
+			return entities.ErrorUsersDoesNotExist
+		}
+
+		return fmt.Errorf("repositories: credential: set: exec: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CredentialRepo) GetByLogin(ctx context.Context, login string) (entities.Credentials, error) {
+	whereStatement := squirrel.Eq{
+		"login": login,
+	}
+
+	sql, args, err := r.Driver.Builder.Select("user_id", "login", "password_hash", "version").
+		From("credentials").
+		Where(whereStatement).
+		ToSql()
+	if err != nil {
+		return entities.Credentials{}, fmt.Errorf("repositories: credential: getByLogin: tosql: %w", err)
+	}
+
+	credentials := entities.Credentials{}
+
+	if err := r.Driver.Pool.QueryRow(ctx, sql, args...).Scan(&credentials.UserID, &credentials.Login, &credentials.PasswordHash, &credentials.Version); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Credentials{}, entities.ErrorInvalidCredentials
+		}
+
+		return entities.Credentials{}, fmt.Errorf("repositories: credential: getByLogin: queryRow: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// GetVersion reports version of the user credentials, missing credentials (the user was deleted among others)
+// make any token of the user unauthorized.
+func (r *CredentialRepo) GetVersion(ctx context.Context, userID string) (int, error) {
+	whereStatement := squirrel.Eq{
+		"user_id": userID,
+	}
+
+	sql, args, err := r.Driver.Builder.Select("version").
+		From("credentials").
+		Where(whereStatement).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("repositories: credential: getVersion: tosql: %w", err)
+	}
+
+	version := 0
+
+	if err := r.Driver.Pool.QueryRow(ctx, sql, args...).Scan(&version); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, entities.ErrorUnauthorized
+		}
+
+		return 0, fmt.Errorf("repositories: credential: getVersion: queryRow: %w", err)
+	}
+
+	return version, nil
+}
+
+// SetAdmin creates admin credentials of the user with that passport, already existing credentials
+// are only promoted so the password changed by the admin survives restarts.
+func (r *CredentialRepo) SetAdmin(ctx context.Context, passportNumber string, credentials entities.Credentials) error {
+	userSelect := squirrel.Select("user_id").
+		Column("?", credentials.Login).
+		Column("?", credentials.PasswordHash).
+		Column("true").
+		From("users").
+		Where(squirrel.Eq{"passport_number": passportNumber})
+
+	sql, args, err := r.Driver.Builder.Insert("credentials").
+		Columns("user_id", "login", "password_hash", "is_admin").
+		Select(userSelect).
+		Suffix("on conflict (user_id) do update set is_admin = true").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repositories: credential: setAdmin: tosql: %w", err)
+	}
+
+	tag, err := r.Driver.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "uq_credentials_login" {
+			return entities.ErrorCredentialsHasAlreadyExistWithThatLogin
+		}
+
+		return fmt.Errorf("repositories: credential: setAdmin: exec: %w", err)
+	}
+
+	if tag.RowsAffected() != 1 {
+		return entities.ErrorUsersDoesNotExist
+	}
+
+	return nil
+}
+
+func (r *CredentialRepo) IsAdmin(ctx context.Context, userID string) (bool, error) {
+	whereStatement := squirrel.Eq{
+		"user_id":  userID,
+		"is_admin": true,
+	}
+
+	adminSelect := squirrel.Select("1").
+		From("credentials").
+		Where(whereStatement)
+
+	sql, args, err := r.Driver.Builder.Select().
+		Column(squirrel.Expr("exists (?)", adminSelect)).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("repositories: credential: isAdmin: tosql: %w", err)
+	}
+
+	isAdmin := false
+
+	if err := r.Driver.Pool.QueryRow(ctx, sql, args...).Scan(&isAdmin); err != nil {
+		return false, fmt.Errorf("repositories: credential: isAdmin: queryRow: %w", err)
+	}
+
+	return isAdmin, nil
+}
+
+func (r *CredentialRepo) HasAdmin(ctx context.Context) (bool, error) {
+	adminSelect := squirrel.Select("1").
+		From("credentials").
+		Where(squirrel.Eq{"is_admin": true})
+
+	sql, args, err := r.Driver.Builder.Select().
+		Column(squirrel.Expr("exists (?)", adminSelect)).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("repositories: credential: hasAdmin: tosql: %w", err)
+	}
+
+	hasAdmin := false
+
+	if err := r.Driver.Pool.QueryRow(ctx, sql, args...).Scan(&hasAdmin); err != nil {
+		return false, fmt.Errorf("repositories: credential: hasAdmin: queryRow: %w", err)
+	}
+
+	return hasAdmin, nil
+}
@@ -0,0 +1,173 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/v1adhope/time-tracker/internal/entities"
+	"github.com/v1adhope/time-tracker/pkg/postgresql"
+)
+
+type ProjectRepo struct {
+	Driver *postgresql.Postgres
+}
+
+func NewProject(d *postgresql.Postgres) *ProjectRepo {
+	return &ProjectRepo{d}
+}
+
+func (r *ProjectRepo) Create(ctx context.Context, project entities.Project) (string, error) {
+	valuesByColumns := squirrel.Eq{
+		"name":        project.Name,
+		"description": project.Description,
+	}
+
+	sql, args, err := r.Driver.Builder.Insert("projects").
+		SetMap(valuesByColumns).
+		Suffix("returning \"project_id\"").
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("repositories: project: create: tosql: %w", err)
+	}
+
+	if err := r.Driver.Pool.QueryRow(ctx, sql, args...).Scan(&project.ID); err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "uq_projects_name" {
+			return "", entities.ErrorProjectHasAlreadyExistWithThatName
+		}
+
+		return "", fmt.Errorf("repositories: project: create: queryRow: %w", err)
+	}
+
+	return project.ID, nil
+}
+
+func (r *ProjectRepo) Delete(ctx context.Context, id string) error {
+	whereStatement := squirrel.Eq{
+		"project_id": id,
+	}
+
+	sql, args, err := r.Driver.Builder.Delete("projects").
+		Where(whereStatement).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repositories: project: delete: tosql: %w", err)
+	}
+
+	tag, err := r.Driver.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("repositories: project: delete: exec: %w", err)
+	}
+
+	if tag.RowsAffected() != 1 {
+		return entities.ErrorProjectsDoesNotExist
+	}
+
+	return nil
+}
+
+func (r *ProjectRepo) Update(ctx context.Context, project entities.Project) error {
+	whereStatement := squirrel.Eq{
+		"project_id": project.ID,
+	}
+
+	valuesByColumns := squirrel.Eq{}
+
+	if project.Name != "" {
+		valuesByColumns["name"] = project.Name
+	}
+
+	if project.Description != "" {
+		valuesByColumns["description"] = project.Description
+	}
+
+	sql, args, err := r.Driver.Builder.Update("projects").
+		Where(whereStatement).
+		SetMap(valuesByColumns).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repositories: project: update: tosql: %w", err)
+	}
+
+	tag, err := r.Driver.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "uq_projects_name" {
+			return entities.ErrorProjectHasAlreadyExistWithThatName
+		}
+
+		return fmt.Errorf("repositories: project: update: exec: %w", err)
+	}
+
+	if tag.RowsAffected() != 1 {
+		return entities.ErrorProjectsDoesNotExist
+	}
+
+	return nil
+}
+
+func (r *ProjectRepo) GetAll(ctx context.Context, pagination entities.ProjectPagination) ([]entities.Project, error) {
+	sql, args, err := r.Driver.Builder.Select("project_id", "name", "description").
+		From("projects").
+		OrderBy("project_id").
+		Limit(setLimitStatement(pagination.Limit)).
+		Offset(setOffsetStatement(pagination.Offset)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("repositories: project: getall: tosql: %w", err)
+	}
+
+	rows, err := r.Driver.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repositories: project: getall: query: %w", err)
+	}
+
+	projects := make([]entities.Project, 0)
+	project := entities.Project{}
+
+	_, err = pgx.ForEachRow(rows, []any{&project.ID, &project.Name, &project.Description}, func() error {
+		projects = append(projects, project)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repositories: project: getall: forEachRow: %w", err)
+	}
+
+	if len(projects) == 0 {
+		return nil, entities.ErrorProjectsDoesNotExist
+	}
+
+	return projects, nil
+}
+
+func (r *ProjectRepo) Get(ctx context.Context, id string) (entities.Project, error) {
+	whereStatement := squirrel.Eq{
+		"project_id": id,
+	}
+
+	sql, args, err := r.Driver.Builder.Select("project_id", "name", "description").
+		From("projects").
+		Where(whereStatement).
+		ToSql()
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("repositories: project: get: tosql: %w", err)
+	}
+
+	project := entities.Project{}
+
+	if err := r.Driver.Pool.QueryRow(ctx, sql, args...).Scan(&project.ID, &project.Name, &project.Description); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Project{}, entities.ErrorProjectsDoesNotExist
+		}
+
+		return entities.Project{}, fmt.Errorf("repositories: project: get: queryRow: %w", err)
+	}
+
+	return project, nil
+}
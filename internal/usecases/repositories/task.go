@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/Masterminds/squirrel"
@@ -14,6 +13,13 @@ import (
 	"github.com/v1adhope/time-tracker/pkg/postgresql"
 )
 
+// taskActiveTimeJoin sums closed intervals of every task, breaks between them aren't counted.
+const taskActiveTimeJoin = "lateral (select sum(task_intervals.finished_at - task_intervals.started_at) as active_time from task_intervals where task_intervals.task_id = tasks.task_id) as intervals on true"
+
+// taskClippedActiveTimeJoin sums intervals overlapping the range clipped by its bounds,
+// a running interval lasts till now. Args are end, start, start, end of the range.
+const taskClippedActiveTimeJoin = "left join lateral (select sum(least(coalesce(task_intervals.finished_at, now()), ?::timestamptz) - greatest(task_intervals.started_at, ?::timestamptz)) as active_time from task_intervals where task_intervals.task_id = tasks.task_id and tstzrange(task_intervals.started_at, coalesce(task_intervals.finished_at, now())) && tstzrange(?::timestamptz, ?::timestamptz, '[]')) as intervals on true"
+
 type TaskRepo struct {
 	Driver *postgresql.Postgres
 }
@@ -22,69 +28,696 @@ func NewTask(d *postgresql.Postgres) *TaskRepo {
 	return &TaskRepo{d}
 }
 
-func (r *TaskRepo) Create(ctx context.Context, userID string) (string, error) {
-	createdAt := time.Now().UTC().Format(time.RFC3339)
+func (r *TaskRepo) Create(ctx context.Context, task entities.Task) (string, error) {
+	task.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	err := pgx.BeginFunc(ctx, r.Driver.Pool, func(tx pgx.Tx) error {
+		if err := r.insert(ctx, tx, &task); err != nil {
+			return fmt.Errorf("repositories: task: create: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", r.mapInsertError(err)
+	}
+
+	return task.ID, nil
+}
+
+// CreateFinished rejects the task overlapping other tasks of the user.
+func (r *TaskRepo) CreateFinished(ctx context.Context, task entities.Task) (string, error) {
+	err := pgx.BeginFunc(ctx, r.Driver.Pool, func(tx pgx.Tx) error {
+		if err := r.lockUser(ctx, tx, task.UserID); err != nil {
+			return fmt.Errorf("repositories: task: createFinished: %w", err)
+		}
+
+		if err := r.insert(ctx, tx, &task); err != nil {
+			return fmt.Errorf("repositories: task: createFinished: %w", err)
+		}
+
+		hasOverlaps, err := r.hasOverlaps(ctx, tx, task.ID)
+		if err != nil {
+			return fmt.Errorf("repositories: task: createFinished: %w", err)
+		}
+
+		if hasOverlaps {
+			return entities.ErrorTaskOverlaps
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", r.mapInsertError(err)
+	}
+
+	return task.ID, nil
+}
+
+func (r *TaskRepo) CreateStoppingRunning(ctx context.Context, task entities.Task) (string, error) {
+	task.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	err := pgx.BeginFunc(ctx, r.Driver.Pool, func(tx pgx.Tx) error {
+		if err := r.stopRunning(ctx, tx, task.UserID, task.CreatedAt); err != nil {
+			return fmt.Errorf("repositories: task: createStoppingRunning: %w", err)
+		}
+
+		if err := r.insert(ctx, tx, &task); err != nil {
+			return fmt.Errorf("repositories: task: createStoppingRunning: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", r.mapInsertError(err)
+	}
+
+	return task.ID, nil
+}
+
+func (r *TaskRepo) insert(ctx context.Context, tx pgx.Tx, task *entities.Task) error {
+	if task.Labels == nil {
+		task.Labels = make([]string, 0)
+	}
+
+	valuesByColumns := squirrel.Eq{
+		"user_id":     task.UserID,
+		"created_at":  task.CreatedAt,
+		"title":       task.Title,
+		"description": task.Description,
+		"labels":      task.Labels,
+	}
+
+	if task.FinishedAt != "" {
+		valuesByColumns["finished_at"] = task.FinishedAt
+	}
+
+	if task.ProjectID != "" {
+		valuesByColumns["project_id"] = task.ProjectID
+	}
+
+	sql, args, err := r.Driver.Builder.Insert("tasks").
+		SetMap(valuesByColumns).
+		Suffix("returning \"task_id\"").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("insert: tosql: %w", err)
+	}
+
+	if err := tx.QueryRow(ctx, sql, args...).Scan(&task.ID); err != nil {
+		return fmt.Errorf("insert: queryRow: %w", err)
+	}
+
+	if err := r.insertInterval(ctx, tx, task.ID, task.CreatedAt, task.FinishedAt); err != nil {
+		return fmt.Errorf("insert: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TaskRepo) mapInsertError(err error) error {
+	var pgErr *pgconn.PgError
+
+	if errors.As(err, &pgErr) {
+		switch pgErr.ConstraintName {
+		case "fk_tasks_users_user_id":
+			return entities.ErrorUsersDoesNotExist
+		case "fk_tasks_projects_project_id":
+			return entities.ErrorProjectsDoesNotExist
+		case "uidx_tasks_user_id_running":
+			return entities.ErrorUserHasRunningTask
+		}
+	}
+
+	return err
+}
+
+func (r *TaskRepo) stopRunning(ctx context.Context, tx pgx.Tx, userID, finishedAt string) error {
+	runningStatement := squirrel.Eq{
+		"user_id":     userID,
+		"finished_at": nil,
+	}
+
+	runningSelect := squirrel.Select("task_id").
+		From("tasks").
+		Where(runningStatement)
+
+	sql, args, err := r.Driver.Builder.Update("task_intervals").
+		Set("finished_at", finishedAt).
+		Where(squirrel.Eq{"finished_at": nil}).
+		Where(squirrel.Expr("task_id in (?)", runningSelect)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("stopRunning: intervals: tosql: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("stopRunning: intervals: exec: %w", err)
+	}
+
+	sql, args, err = r.Driver.Builder.Update("tasks").
+		Set("finished_at", finishedAt).
+		Where(runningStatement).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("stopRunning: tasks: tosql: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("stopRunning: tasks: exec: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TaskRepo) SetFinishedAt(ctx context.Context, id string) (string, error) {
+	finishedAt := time.Now().UTC().Format(time.RFC3339)
+
+	valuesByColumns := squirrel.Eq{
+		"finished_at": finishedAt,
+	}
+
+	whereStatement := squirrel.Eq{
+		"task_id":     id,
+		"finished_at": nil,
+	}
+
+	sql, args, err := r.Driver.Builder.Update("tasks").
+		SetMap(valuesByColumns).
+		Where(whereStatement).
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("repositories: task: setFinishedAt: tosql: %w", err)
+	}
+
+	err = pgx.BeginFunc(ctx, r.Driver.Pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("repositories: task: setFinishedAt: exec: %w", err)
+		}
+
+		if tag.RowsAffected() != 1 {
+			return entities.ErrorTaskAlreadyFinished
+		}
+
+		if _, err := r.closeInterval(ctx, tx, id, finishedAt); err != nil {
+			return fmt.Errorf("repositories: task: setFinishedAt: %w", err)
+		}
+
+		return nil
+	})
+	if errors.Is(err, entities.ErrorTaskAlreadyFinished) {
+		originalFinishedAt, err := r.getFinishedAt(ctx, id)
+		if err != nil {
+			return "", err
+		}
+
+		if originalFinishedAt == nil {
+			return "", fmt.Errorf("repositories: task: setFinishedAt: task was modified concurrently")
+		}
+
+		return originalFinishedAt.UTC().Format(time.RFC3339), entities.ErrorTaskAlreadyFinished
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return finishedAt, nil
+}
+
+func (r *TaskRepo) Pause(ctx context.Context, id string) (string, error) {
+	pausedAt := time.Now().UTC().Format(time.RFC3339)
+
+	closed, err := r.closeInterval(ctx, r.Driver.Pool, id, pausedAt)
+	if err != nil {
+		return "", fmt.Errorf("repositories: task: pause: %w", err)
+	}
+
+	if closed != 1 {
+		finishedAt, err := r.getFinishedAt(ctx, id)
+		if err != nil {
+			return "", err
+		}
+
+		if finishedAt != nil {
+			return "", entities.ErrorTaskAlreadyFinished
+		}
+
+		return "", entities.ErrorTaskHasAlreadyPaused
+	}
+
+	return pausedAt, nil
+}
+
+func (r *TaskRepo) Resume(ctx context.Context, id string) (string, error) {
+	resumedAt := time.Now().UTC().Format(time.RFC3339)
+
+	whereStatement := squirrel.Eq{
+		"task_id":     id,
+		"finished_at": nil,
+	}
+
+	selectStatement := squirrel.Select("task_id").
+		Column("?::timestamptz", resumedAt).
+		From("tasks").
+		Where(whereStatement)
+
+	sql, args, err := r.Driver.Builder.Insert("task_intervals").
+		Columns("task_id", "started_at").
+		Select(selectStatement).
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("repositories: task: resume: tosql: %w", err)
+	}
+
+	tag, err := r.Driver.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "uidx_task_intervals_task_id_running" {
+			return "", entities.ErrorTaskIsNotPaused
+		}
+
+		return "", fmt.Errorf("repositories: task: resume: exec: %w", err)
+	}
+
+	if tag.RowsAffected() != 1 {
+		if _, err := r.getFinishedAt(ctx, id); err != nil {
+			return "", err
+		}
+
+		return "", entities.ErrorTaskAlreadyFinished
+	}
+
+	return resumedAt, nil
+}
+
+func (r *TaskRepo) getFinishedAt(ctx context.Context, id string) (*time.Time, error) {
+	whereStatement := squirrel.Eq{
+		"task_id": id,
+	}
+
+	sql, args, err := r.Driver.Builder.Select("finished_at").
+		From("tasks").
+		Where(whereStatement).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("repositories: task: getFinishedAt: tosql: %w", err)
+	}
+
+	var finishedAt *time.Time
+
+	if err := r.Driver.Pool.QueryRow(ctx, sql, args...).Scan(&finishedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrorTaskDoesNotExist
+		}
+
+		return nil, fmt.Errorf("repositories: task: getFinishedAt: queryRow: %w", err)
+	}
+
+	return finishedAt, nil
+}
+
+// insertInterval opens interval if finishedAt is empty.
+func (r *TaskRepo) insertInterval(ctx context.Context, executor executor, taskID, startedAt, finishedAt string) error {
+	valuesByColumns := squirrel.Eq{
+		"task_id":    taskID,
+		"started_at": startedAt,
+	}
+
+	if finishedAt != "" {
+		valuesByColumns["finished_at"] = finishedAt
+	}
+
+	sql, args, err := r.Driver.Builder.Insert("task_intervals").
+		SetMap(valuesByColumns).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("insertInterval: tosql: %w", err)
+	}
+
+	if _, err := executor.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("insertInterval: exec: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TaskRepo) closeInterval(ctx context.Context, executor executor, taskID, finishedAt string) (int64, error) {
+	valuesByColumns := squirrel.Eq{
+		"finished_at": finishedAt,
+	}
+
+	whereStatement := squirrel.Eq{
+		"task_id":     taskID,
+		"finished_at": nil,
+	}
+
+	sql, args, err := r.Driver.Builder.Update("task_intervals").
+		SetMap(valuesByColumns).
+		Where(whereStatement).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("closeInterval: tosql: %w", err)
+	}
+
+	tag, err := executor.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("closeInterval: exec: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *TaskRepo) Update(ctx context.Context, task entities.Task) error {
+	whereStatement := squirrel.Eq{
+		"task_id": task.ID,
+	}
+
+	valuesByColumns := squirrel.Eq{}
+
+	if task.CreatedAt != "" {
+		valuesByColumns["created_at"] = task.CreatedAt
+	}
+
+	if task.FinishedAt != "" {
+		valuesByColumns["finished_at"] = task.FinishedAt
+	}
+
+	if task.Title != "" {
+		valuesByColumns["title"] = task.Title
+	}
+
+	if task.Description != "" {
+		valuesByColumns["description"] = task.Description
+	}
+
+	if task.Labels != nil {
+		valuesByColumns["labels"] = task.Labels
+	}
+
+	if task.ProjectID != "" {
+		valuesByColumns["project_id"] = task.ProjectID
+	}
+
+	sql, args, err := r.Driver.Builder.Update("tasks").
+		Where(whereStatement).
+		SetMap(valuesByColumns).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repositories: task: update: tosql: %w", err)
+	}
+
+	isTimeChanged := task.CreatedAt != "" || task.FinishedAt != ""
+
+	err = pgx.BeginFunc(ctx, r.Driver.Pool, func(tx pgx.Tx) error {
+		if isTimeChanged {
+			if err := r.lockTaskUser(ctx, tx, task.ID); err != nil {
+				return fmt.Errorf("repositories: task: update: %w", err)
+			}
+		}
+
+		tag, err := tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf("repositories: task: update: exec: %w", err)
+		}
+
+		if tag.RowsAffected() != 1 {
+			return entities.ErrorTaskDoesNotExist
+		}
+
+		if task.CreatedAt != "" {
+			if err := r.setIntervalBound(ctx, tx, task.ID, "started_at", task.CreatedAt, "started_at"); err != nil {
+				return fmt.Errorf("repositories: task: update: %w", err)
+			}
+		}
+
+		if task.FinishedAt != "" {
+			if err := r.setIntervalBound(ctx, tx, task.ID, "finished_at", task.FinishedAt, "started_at desc"); err != nil {
+				return fmt.Errorf("repositories: task: update: %w", err)
+			}
+		}
+
+		if isTimeChanged {
+			hasOverlaps, err := r.hasOverlaps(ctx, tx, task.ID)
+			if err != nil {
+				return fmt.Errorf("repositories: task: update: %w", err)
+			}
+
+			if hasOverlaps {
+				return entities.ErrorTaskOverlaps
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "fk_tasks_projects_project_id" {
+			return entities.ErrorProjectsDoesNotExist
+		}
+
+		if errors.As(err, &pgErr) && (pgErr.ConstraintName == "chk_tasks_finished_at" || pgErr.ConstraintName == "chk_task_intervals_finished_at") {
+			return entities.ErrorTaskInvalidTimeRange
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// setIntervalBound moves the bound of the first interval in the given order,
+// it's the way to keep intervals inside of edited task.
+func (r *TaskRepo) setIntervalBound(ctx context.Context, tx pgx.Tx, taskID, column, value, orderBy string) error {
+	intervalSelect := squirrel.Select("interval_id").
+		From("task_intervals").
+		Where(squirrel.Eq{"task_id": taskID}).
+		OrderBy(orderBy).
+		Limit(1)
+
+	sql, args, err := r.Driver.Builder.Update("task_intervals").
+		Set(column, value).
+		Where(squirrel.Expr("interval_id = (?)", intervalSelect)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("setIntervalBound: tosql: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("setIntervalBound: exec: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TaskRepo) Delete(ctx context.Context, id string) error {
+	whereStatement := squirrel.Eq{
+		"task_id": id,
+	}
+
+	sql, args, err := r.Driver.Builder.Delete("tasks").
+		Where(whereStatement).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repositories: task: delete: tosql: %w", err)
+	}
+
+	tag, err := r.Driver.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("repositories: task: delete: exec: %w", err)
+	}
+
+	if tag.RowsAffected() != 1 {
+		return entities.ErrorTaskDoesNotExist
+	}
+
+	return nil
+}
+
+type taskDTO struct {
+	ID          string
+	CreatedAt   time.Time
+	FinishedAt  *time.Time
+	UserID      string
+	Title       string
+	Description string
+	Labels      []string
+	ProjectID   *string
+}
+
+func (r *TaskRepo) Get(ctx context.Context, id string) (entities.Task, error) {
+	whereStatement := squirrel.Eq{
+		"task_id": id,
+	}
+
+	sql, args, err := r.Driver.Builder.Select("task_id", "created_at", "finished_at", "user_id", "title", "description", "labels", "project_id").
+		From("tasks").
+		Where(whereStatement).
+		ToSql()
+	if err != nil {
+		return entities.Task{}, fmt.Errorf("repositories: task: get: tosql: %w", err)
+	}
+
+	dto := taskDTO{}
+
+	if err := r.Driver.Pool.QueryRow(ctx, sql, args...).Scan(&dto.ID, &dto.CreatedAt, &dto.FinishedAt, &dto.UserID, &dto.Title, &dto.Description, &dto.Labels, &dto.ProjectID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Task{}, entities.ErrorTaskDoesNotExist
+		}
+
+		return entities.Task{}, fmt.Errorf("repositories: task: get: queryRow: %w", err)
+	}
 
 	task := entities.Task{
-		UserID:    userID,
-		CreatedAt: createdAt,
+		ID:          dto.ID,
+		CreatedAt:   dto.CreatedAt.UTC().Format(time.RFC3339),
+		UserID:      dto.UserID,
+		Title:       dto.Title,
+		Description: dto.Description,
+		Labels:      dto.Labels,
 	}
 
-	valuesByColumns := squirrel.Eq{
-		"user_id":    task.UserID,
-		"created_at": task.CreatedAt,
+	if dto.FinishedAt != nil {
+		task.FinishedAt = dto.FinishedAt.UTC().Format(time.RFC3339)
 	}
 
-	sql, args, err := r.Driver.Builder.Insert("tasks").
-		SetMap(valuesByColumns).
-		Suffix("returning \"task_id\"").
+	if dto.ProjectID != nil {
+		task.ProjectID = *dto.ProjectID
+	}
+
+	return task, nil
+}
+
+// lockUser serializes writes changing time of the user's tasks,
+// so the overlap check and the write can't interleave with a concurrent one.
+func (r *TaskRepo) lockUser(ctx context.Context, tx pgx.Tx, userID string) error {
+	sql, args, err := r.Driver.Builder.Select("user_id").
+		From("users").
+		Where(squirrel.Eq{"user_id": userID}).
+		Suffix("for update").
 		ToSql()
 	if err != nil {
-		return "", fmt.Errorf("repositories: task: create: tosql: %w", err)
+		return fmt.Errorf("lockUser: tosql: %w", err)
 	}
 
-	if err := r.Driver.Pool.QueryRow(ctx, sql, args...).Scan(&task.ID); err != nil {
-		var pgErr *pgconn.PgError
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("lockUser: exec: %w", err)
+	}
 
-		if errors.As(err, &pgErr) && pgErr.ConstraintName == "fk_tasks_users_user_id" {
-			return "", entities.ErrorUsersDoesNotExist
+	return nil
+}
+
+// lockTaskUser is lockUser for the owner of the task.
+func (r *TaskRepo) lockTaskUser(ctx context.Context, tx pgx.Tx, taskID string) error {
+	sql, args, err := r.Driver.Builder.Select("users.user_id").
+		From("users").
+		Join("tasks on tasks.user_id = users.user_id").
+		Where(squirrel.Eq{"tasks.task_id": taskID}).
+		Suffix("for update of users").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("lockTaskUser: tosql: %w", err)
+	}
+
+	userID := ""
+
+	if err := tx.QueryRow(ctx, sql, args...).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.ErrorTaskDoesNotExist
 		}
 
-		return "", fmt.Errorf("repositories: task: create: queryRow: %w", err)
+		return fmt.Errorf("lockTaskUser: queryRow: %w", err)
 	}
 
-	return task.ID, nil
+	return nil
 }
 
-func (r *TaskRepo) SetFinishedAt(ctx context.Context, id string) (string, error) {
-	finishedAt := time.Now().UTC().Format(time.RFC3339)
+// hasOverlaps checks the already written task against other tasks of its user,
+// empty finished_at is a running task which lasts infinitely.
+func (r *TaskRepo) hasOverlaps(ctx context.Context, tx pgx.Tx, taskID string) (bool, error) {
+	whereStatement := squirrel.And{
+		squirrel.Eq{"edited.task_id": taskID},
+		squirrel.Expr("other.task_id <> edited.task_id"),
+		squirrel.Expr("tstzrange(other.created_at, other.finished_at) && tstzrange(edited.created_at, edited.finished_at)"),
+	}
 
-	valuesByColumns := squirrel.Eq{
-		"finished_at": finishedAt,
+	overlapSelect := squirrel.Select("1").
+		From("tasks as edited").
+		Join("tasks as other on other.user_id = edited.user_id").
+		Where(whereStatement)
+
+	sql, args, err := r.Driver.Builder.Select().
+		Column(squirrel.Expr("exists (?)", overlapSelect)).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("hasOverlaps: tosql: %w", err)
 	}
 
+	var hasOverlaps bool
+
+	if err := tx.QueryRow(ctx, sql, args...).Scan(&hasOverlaps); err != nil {
+		return false, fmt.Errorf("hasOverlaps: queryRow: %w", err)
+	}
+
+	return hasOverlaps, nil
+}
+
+type taskOverlapDTO struct {
+	TaskID        string
+	OverlapTaskID string
+	StartedAt     time.Time
+	FinishedAt    *time.Time
+}
+
+func (r *TaskRepo) GetOverlaps(ctx context.Context, userID string) ([]entities.TaskOverlap, error) {
 	whereStatement := squirrel.Eq{
-		"task_id": id,
+		"a.user_id": userID,
 	}
 
-	sql, args, err := r.Driver.Builder.Update("tasks").
-		SetMap(valuesByColumns).
+	sql, args, err := r.Driver.Builder.Select("a.task_id", "b.task_id", "greatest(a.created_at, b.created_at) as started_at", "least(a.finished_at, b.finished_at) as finished_at").
+		From("tasks as a").
+		Join("tasks as b on a.user_id = b.user_id and a.task_id < b.task_id and tstzrange(a.created_at, a.finished_at) && tstzrange(b.created_at, b.finished_at)").
 		Where(whereStatement).
+		OrderBy("started_at", "a.task_id").
 		ToSql()
 	if err != nil {
-		return "", fmt.Errorf("repositories: task: setFinishedAt: tosql: %w", err)
+		return nil, fmt.Errorf("repositories: task: getOverlaps: tosql: %w", err)
 	}
 
-	tag, err := r.Driver.Pool.Exec(ctx, sql, args...)
+	rows, err := r.Driver.Pool.Query(ctx, sql, args...)
 	if err != nil {
-		return "", fmt.Errorf("repositories: task: setFinishedAt: exec: %w", err)
+		return nil, fmt.Errorf("repositories: task: getOverlaps: query: %w", err)
 	}
 
-	if tag.RowsAffected() != 1 {
-		return "", entities.ErrorTaskDoesNotExist
+	overlaps := make([]entities.TaskOverlap, 0)
+	overlapDTO := taskOverlapDTO{}
+
+	_, err = pgx.ForEachRow(rows, []any{&overlapDTO.TaskID, &overlapDTO.OverlapTaskID, &overlapDTO.StartedAt, &overlapDTO.FinishedAt}, func() error {
+		overlap := entities.TaskOverlap{
+			TaskID:        overlapDTO.TaskID,
+			OverlapTaskID: overlapDTO.OverlapTaskID,
+			StartedAt:     overlapDTO.StartedAt.UTC().Format(time.RFC3339),
+		}
+
+		if overlapDTO.FinishedAt != nil {
+			overlap.FinishedAt = overlapDTO.FinishedAt.UTC().Format(time.RFC3339)
+			overlap.OverlapDuration = overlapDTO.FinishedAt.Sub(overlapDTO.StartedAt)
+		}
+
+		overlaps = append(overlaps, overlap)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repositories: task: getOverlaps: forEachRow: %w", err)
 	}
 
-	return finishedAt, nil
+	if len(overlaps) == 0 {
+		return nil, entities.ErrorNoAnyTaskOverlaps
+	}
+
+	return overlaps, nil
 }
 
 type taskSummaryTimeDTO struct {
@@ -92,77 +725,492 @@ type taskSummaryTimeDTO struct {
 	CreatedAt   time.Time
 	FinishedAt  *time.Time
 	SummaryTime *time.Duration
+	Title       string
+	Description string
+	Labels      []string
+	ProjectID   *string
 }
 
-func (r *TaskRepo) GetReportSummaryTime(ctx context.Context, userID string, sort entities.TaskSort) ([]entities.TaskSummary, error) {
+func (r *TaskRepo) GetReportSummaryTime(ctx context.Context, userID string, sort entities.TaskSort, pagination entities.TaskPagination) (entities.TaskSummaryPage, error) {
+	loc, err := loadLocation(sort.Timezone)
+	if err != nil {
+		return entities.TaskSummaryPage{}, fmt.Errorf("repositories: task: getReportSummaryTime: loadLocation: %w", err)
+	}
+
 	whereStatement := squirrel.Eq{
 		"user_id": userID,
 	}
 
-	sql, args, err := r.Driver.Builder.Select("task_id", "created_at", "finished_at", "finished_at - created_at as summary_time").
+	isPaginated := pagination != entities.TaskPagination{}
+	isKeyset := sort.Order.By == entities.TaskOrderByID
+
+	builder := r.Driver.Builder.Select("task_id", "created_at", "finished_at", "intervals.active_time as summary_time", "title", "description", "labels", "project_id").
 		From("tasks").
+		JoinClause(buildTaskActiveTimeJoin(sort)).
 		Where(whereStatement).
-		Where(r.buildGetReportSummaryTimeWhereSortStatement(sort)).
-		OrderBy("summary_time desc").
-		ToSql()
+		Where(r.buildGetReportSummaryTimeWhereSortStatement(sort))
+
+	builder = builder.OrderBy(buildGetReportSummaryTimeOrderBy(sort.Order)...)
+
+	switch {
+	case pagination.Cursor != "" && sort.Order.Desc:
+		builder = builder.Where(squirrel.Lt{"task_id": pagination.Cursor})
+	case pagination.Cursor != "":
+		builder = builder.Where(squirrel.Gt{"task_id": pagination.Cursor})
+	}
+
+	if isPaginated {
+		builder = builder.
+			Limit(setLimitStatement(pagination.Limit)).
+			Offset(setOffsetStatement(pagination.Offset))
+	}
+
+	sql, args, err := builder.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("repositories: task: getReportSummaryTime: tosql: %w", err)
+		return entities.TaskSummaryPage{}, fmt.Errorf("repositories: task: getReportSummaryTime: tosql: %w", err)
 	}
 
 	rows, err := r.Driver.Pool.Query(ctx, sql, args...)
 	if err != nil {
-		return nil, fmt.Errorf("repositories: task: getReportSummaryTime: query: %w", err)
+		return entities.TaskSummaryPage{}, fmt.Errorf("repositories: task: getReportSummaryTime: query: %w", err)
 	}
 
 	tasks := make([]entities.TaskSummary, 0)
 	taskDTO := taskSummaryTimeDTO{}
 
-	_, err = pgx.ForEachRow(rows, []any{&taskDTO.ID, &taskDTO.CreatedAt, &taskDTO.FinishedAt, &taskDTO.SummaryTime}, func() error {
+	_, err = pgx.ForEachRow(rows, []any{&taskDTO.ID, &taskDTO.CreatedAt, &taskDTO.FinishedAt, &taskDTO.SummaryTime, &taskDTO.Title, &taskDTO.Description, &taskDTO.Labels, &taskDTO.ProjectID}, func() error {
 		task := entities.TaskSummary{
-			ID:        taskDTO.ID,
-			CreatedAt: taskDTO.CreatedAt.Format(time.RFC3339),
+			ID:          taskDTO.ID,
+			CreatedAt:   taskDTO.CreatedAt.In(loc).Format(time.RFC3339),
+			Title:       taskDTO.Title,
+			Description: taskDTO.Description,
+			Labels:      taskDTO.Labels,
 		}
 
 		if taskDTO.FinishedAt != nil {
-			task.FinishedAt = taskDTO.FinishedAt.Format(time.RFC3339)
+			task.FinishedAt = taskDTO.FinishedAt.In(loc).Format(time.RFC3339)
 		}
 
 		if taskDTO.SummaryTime != nil {
-			task.SummaryTime = strings.TrimRight(taskDTO.SummaryTime.Round(time.Minute).String(), "0s")
+			task.SummaryDuration = *taskDTO.SummaryTime
+			task.HasDuration = true
+		}
+
+		if taskDTO.ProjectID != nil {
+			task.ProjectID = *taskDTO.ProjectID
 		}
 
 		tasks = append(tasks, task)
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("repositories: task: getReportSummaryTime: forEachRow: %w", err)
+		return entities.TaskSummaryPage{}, fmt.Errorf("repositories: task: getReportSummaryTime: forEachRow: %w", err)
 	}
 
 	if len(tasks) == 0 {
+		return entities.TaskSummaryPage{}, entities.ErrorNoAnyTasksForThisUser
+	}
+
+	page := entities.TaskSummaryPage{
+		Tasks: tasks,
+		Total: len(tasks),
+	}
+
+	if !isPaginated {
+		return page, nil
+	}
+
+	if page.Total, err = r.countReportSummaryTime(ctx, userID, sort); err != nil {
+		return entities.TaskSummaryPage{}, fmt.Errorf("repositories: task: getReportSummaryTime: %w", err)
+	}
+
+	if isKeyset && uint64(len(tasks)) == setLimitStatement(pagination.Limit) {
+		page.NextCursor = tasks[len(tasks)-1].ID
+	}
+
+	return page, nil
+}
+
+// countReportSummaryTime counts all tasks of the report regardless of the page.
+func (r *TaskRepo) countReportSummaryTime(ctx context.Context, userID string, sort entities.TaskSort) (int, error) {
+	sql, args, err := r.Driver.Builder.Select("count(*)").
+		From("tasks").
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(r.buildGetReportSummaryTimeWhereSortStatement(sort)).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("countReportSummaryTime: tosql: %w", err)
+	}
+
+	total := 0
+
+	if err := r.Driver.Pool.QueryRow(ctx, sql, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("countReportSummaryTime: queryRow: %w", err)
+	}
+
+	return total, nil
+}
+
+type taskSummaryRecordDTO struct {
+	taskSummaryTimeDTO
+	Surname          string
+	Name             string
+	TotalTasksCount  int
+	TotalSummaryTime *time.Duration
+}
+
+// EachReportSummaryTime walks over the same rows as GetReportSummaryTime without collecting them,
+// totals are calculated by window functions so they're known since the first row.
+func (r *TaskRepo) EachReportSummaryTime(ctx context.Context, userID string, sort entities.TaskSort, fn func(record entities.TaskSummaryRecord) error) error {
+	loc, err := loadLocation(sort.Timezone)
+	if err != nil {
+		return fmt.Errorf("repositories: task: eachReportSummaryTime: loadLocation: %w", err)
+	}
+
+	whereStatement := squirrel.Eq{
+		"user_id": userID,
+	}
+
+	sql, args, err := r.Driver.Builder.Select(
+		"task_id", "created_at", "finished_at", "intervals.active_time as summary_time", "title", "description", "labels", "project_id",
+		"users.surname", "users.name", "count(*) over ()", "sum(intervals.active_time) over ()",
+	).
+		From("tasks").
+		Join("users using (user_id)").
+		JoinClause(buildTaskActiveTimeJoin(sort)).
+		Where(whereStatement).
+		Where(r.buildGetReportSummaryTimeWhereSortStatement(sort)).
+		OrderBy(buildGetReportSummaryTimeOrderBy(sort.Order)...).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("repositories: task: eachReportSummaryTime: tosql: %w", err)
+	}
+
+	rows, err := r.Driver.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("repositories: task: eachReportSummaryTime: query: %w", err)
+	}
+
+	recordDTO := taskSummaryRecordDTO{}
+
+	tag, err := pgx.ForEachRow(rows, []any{
+		&recordDTO.ID, &recordDTO.CreatedAt, &recordDTO.FinishedAt, &recordDTO.SummaryTime, &recordDTO.Title, &recordDTO.Description, &recordDTO.Labels, &recordDTO.ProjectID,
+		&recordDTO.Surname, &recordDTO.Name, &recordDTO.TotalTasksCount, &recordDTO.TotalSummaryTime,
+	}, func() error {
+		record := entities.TaskSummaryRecord{
+			User: entities.User{
+				ID:      userID,
+				Surname: recordDTO.Surname,
+				Name:    recordDTO.Name,
+			},
+			Task: entities.TaskSummary{
+				ID:          recordDTO.ID,
+				CreatedAt:   recordDTO.CreatedAt.In(loc).Format(time.RFC3339),
+				Title:       recordDTO.Title,
+				Description: recordDTO.Description,
+				Labels:      recordDTO.Labels,
+			},
+			TotalTasksCount: recordDTO.TotalTasksCount,
+		}
+
+		if recordDTO.FinishedAt != nil {
+			record.Task.FinishedAt = recordDTO.FinishedAt.In(loc).Format(time.RFC3339)
+		}
+
+		if recordDTO.SummaryTime != nil {
+			record.Task.SummaryDuration = *recordDTO.SummaryTime
+			record.Task.HasDuration = true
+		}
+
+		if recordDTO.ProjectID != nil {
+			record.Task.ProjectID = *recordDTO.ProjectID
+		}
+
+		if recordDTO.TotalSummaryTime != nil {
+			record.TotalSummaryDuration = *recordDTO.TotalSummaryTime
+		}
+
+		return fn(record)
+	})
+	if err != nil {
+		return fmt.Errorf("repositories: task: eachReportSummaryTime: forEachRow: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entities.ErrorNoAnyTasksForThisUser
+	}
+
+	return nil
+}
+
+type projectSummaryTimeDTO struct {
+	ProjectID   *string
+	ProjectName *string
+	TasksCount  int
+	SummaryTime *time.Duration
+}
+
+func (r *TaskRepo) GetReportSummaryTimeByProject(ctx context.Context, userID string, sort entities.TaskSort) ([]entities.ProjectSummary, error) {
+	whereStatement := squirrel.Eq{
+		"user_id": userID,
+	}
+
+	sql, args, err := r.Driver.Builder.Select("project_id", "projects.name", "count(*)", "sum(intervals.active_time) as summary_time").
+		From("tasks").
+		JoinClause(buildTaskActiveTimeJoin(sort)).
+		LeftJoin("projects using (project_id)").
+		Where(whereStatement).
+		Where(r.buildGetReportSummaryTimeWhereSortStatement(sort)).
+		GroupBy("project_id", "projects.name").
+		OrderBy("summary_time desc nulls last").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("repositories: task: getReportSummaryTimeByProject: tosql: %w", err)
+	}
+
+	rows, err := r.Driver.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repositories: task: getReportSummaryTimeByProject: query: %w", err)
+	}
+
+	projects := make([]entities.ProjectSummary, 0)
+	projectDTO := projectSummaryTimeDTO{}
+
+	_, err = pgx.ForEachRow(rows, []any{&projectDTO.ProjectID, &projectDTO.ProjectName, &projectDTO.TasksCount, &projectDTO.SummaryTime}, func() error {
+		project := entities.ProjectSummary{
+			TasksCount: projectDTO.TasksCount,
+		}
+
+		if projectDTO.ProjectID != nil {
+			project.ProjectID = *projectDTO.ProjectID
+		}
+
+		if projectDTO.ProjectName != nil {
+			project.ProjectName = *projectDTO.ProjectName
+		}
+
+		if projectDTO.SummaryTime != nil {
+			project.SummaryDuration = *projectDTO.SummaryTime
+		}
+
+		projects = append(projects, project)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repositories: task: getReportSummaryTimeByProject: forEachRow: %w", err)
+	}
+
+	if len(projects) == 0 {
 		return nil, entities.ErrorNoAnyTasksForThisUser
 	}
 
-	return tasks, nil
+	return projects, nil
+}
+
+type periodSummaryTimeDTO struct {
+	Period      time.Time
+	TasksCount  int
+	SummaryTime *time.Duration
+}
+
+func (r *TaskRepo) GetReportSummaryTimeByPeriod(ctx context.Context, userID, groupBy string, sort entities.TaskSort) (entities.TaskPeriodReport, error) {
+	loc, err := loadLocation(sort.Timezone)
+	if err != nil {
+		return entities.TaskPeriodReport{}, fmt.Errorf("repositories: task: getReportSummaryTimeByPeriod: loadLocation: %w", err)
+	}
+
+	whereStatement := squirrel.Eq{
+		"user_id": userID,
+	}
+
+	sql, args, err := r.Driver.Builder.Select().
+		Column(squirrel.Expr("date_trunc(?, created_at at time zone ?) at time zone ? as period", groupBy, loc.String(), loc.String())).
+		Columns("count(*)", "sum(intervals.active_time) as summary_time").
+		From("tasks").
+		JoinClause(buildTaskActiveTimeJoin(sort)).
+		Where(whereStatement).
+		Where(r.buildGetReportSummaryTimeWhereSortStatement(sort)).
+		GroupBy("period").
+		OrderBy("period").
+		ToSql()
+	if err != nil {
+		return entities.TaskPeriodReport{}, fmt.Errorf("repositories: task: getReportSummaryTimeByPeriod: tosql: %w", err)
+	}
+
+	rows, err := r.Driver.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return entities.TaskPeriodReport{}, fmt.Errorf("repositories: task: getReportSummaryTimeByPeriod: query: %w", err)
+	}
+
+	report := entities.TaskPeriodReport{
+		Periods: make([]entities.TaskPeriodSummary, 0),
+	}
+	periodDTO := periodSummaryTimeDTO{}
+
+	_, err = pgx.ForEachRow(rows, []any{&periodDTO.Period, &periodDTO.TasksCount, &periodDTO.SummaryTime}, func() error {
+		period := entities.TaskPeriodSummary{
+			Period:     periodDTO.Period.In(loc).Format(time.RFC3339),
+			TasksCount: periodDTO.TasksCount,
+		}
+
+		if periodDTO.SummaryTime != nil {
+			period.SummaryDuration = *periodDTO.SummaryTime
+			report.SummaryDuration += *periodDTO.SummaryTime
+		}
+
+		report.Periods = append(report.Periods, period)
+		report.TasksCount += periodDTO.TasksCount
+		return nil
+	})
+	if err != nil {
+		return entities.TaskPeriodReport{}, fmt.Errorf("repositories: task: getReportSummaryTimeByPeriod: forEachRow: %w", err)
+	}
+
+	if len(report.Periods) == 0 {
+		return entities.TaskPeriodReport{}, entities.ErrorNoAnyTasksForThisUser
+	}
+
+	return report, nil
+}
+
+type userSummaryTimeDTO struct {
+	UserID      string
+	Surname     string
+	Name        string
+	Timezone    string
+	TasksCount  int
+	SummaryTime time.Duration
+}
+
+func (r *TaskRepo) GetReportSummaryTimeByUsers(ctx context.Context, representation entities.UserSummaryRepresentation) ([]entities.UserSummary, error) {
+	totalsSelect := squirrel.Select("count(*) as tasks_count", "sum(intervals.active_time) as summary_time").
+		From("tasks").
+		JoinClause(buildTaskActiveTimeJoin(representation.Sort)).
+		Where("tasks.user_id = users.user_id").
+		Where(r.buildGetReportSummaryTimeWhereSortStatement(representation.Sort))
+
+	order := "desc"
+
+	if representation.Order == "asc" {
+		order = "asc"
+	}
+
+	sql, args, err := r.Driver.Builder.Select("users.user_id", "surname", "name", "timezone", "totals.tasks_count", "coalesce(totals.summary_time, interval '0') as summary_time").
+		From("users").
+		JoinClause(squirrel.Expr("left join lateral (?) as totals on true", totalsSelect)).
+		Where(buildUserFilterStatement(representation.Filter)).
+		OrderBy(fmt.Sprintf("summary_time %s", order), "users.user_id").
+		Limit(setLimitStatement(representation.Pagination.Limit)).
+		Offset(setOffsetStatement(representation.Pagination.Offset)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("repositories: task: getReportSummaryTimeByUsers: tosql: %w", err)
+	}
+
+	rows, err := r.Driver.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("repositories: task: getReportSummaryTimeByUsers: query: %w", err)
+	}
+
+	users := make([]entities.UserSummary, 0)
+	userDTO := userSummaryTimeDTO{}
+
+	_, err = pgx.ForEachRow(rows, []any{&userDTO.UserID, &userDTO.Surname, &userDTO.Name, &userDTO.Timezone, &userDTO.TasksCount, &userDTO.SummaryTime}, func() error {
+		user := entities.UserSummary{
+			UserID:     userDTO.UserID,
+			Surname:    userDTO.Surname,
+			Name:       userDTO.Name,
+			Timezone:   userDTO.Timezone,
+			TasksCount: userDTO.TasksCount,
+		}
+
+		user.SummaryDuration = userDTO.SummaryTime
+
+		users = append(users, user)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("repositories: task: getReportSummaryTimeByUsers: forEachRow: %w", err)
+	}
+
+	if len(users) == 0 {
+		return nil, entities.ErrorUsersDoesNotExist
+	}
+
+	return users, nil
+}
+
+// buildGetReportSummaryTimeOrderBy keeps running tasks, which have no finished at and summary time yet,
+// at the end of ascending order and at the beginning of descending one, task id breaks ties.
+func buildGetReportSummaryTimeOrderBy(order entities.TaskOrder) []string {
+	column := "summary_time"
+
+	switch order.By {
+	case entities.TaskOrderByCreatedAt:
+		column = "created_at"
+	case entities.TaskOrderByFinishedAt:
+		column = "finished_at"
+	case entities.TaskOrderByID:
+		if order.Desc {
+			return []string{"task_id desc"}
+		}
+
+		return []string{"task_id"}
+	case "":
+		order.Desc = true
+	}
+
+	direction := "asc nulls last"
+	if order.Desc {
+		direction = "desc nulls first"
+	}
+
+	return []string{fmt.Sprintf("%s %s", column, direction), "task_id"}
+}
+
+// buildTaskActiveTimeJoin clips active time by the range in overlap mode only,
+// contained tasks lie inside of it entirely.
+func buildTaskActiveTimeJoin(sort entities.TaskSort) squirrel.Sqlizer {
+	if sort.Mode != entities.TaskRangeModeOverlap || sort.StartTime == "" && sort.EndTime == "" {
+		return squirrel.Expr("left join " + taskActiveTimeJoin)
+	}
+
+	startTime, endTime := nullableTime(sort.StartTime), nullableTime(sort.EndTime)
+
+	return squirrel.Expr(taskClippedActiveTimeJoin, endTime, startTime, startTime, endTime)
 }
 
 func (r *TaskRepo) buildGetReportSummaryTimeWhereSortStatement(sort entities.TaskSort) squirrel.And {
-	if sort.StartTime == "" && sort.EndTime == "" {
+	if sort.StartTime == "" && sort.EndTime == "" && sort.ProjectID == "" {
 		return nil
 	}
 
 	statement := squirrel.And{}
 
-	if sort.StartTime != "" {
+	if sort.Mode == entities.TaskRangeModeOverlap && (sort.StartTime != "" || sort.EndTime != "") {
+		statement = append(statement, squirrel.Expr(
+			"tstzrange(created_at, coalesce(finished_at, now())) && tstzrange(?::timestamptz, ?::timestamptz, '[]')",
+			nullableTime(sort.StartTime), nullableTime(sort.EndTime),
+		))
+	}
+
+	if sort.Mode != entities.TaskRangeModeOverlap && sort.StartTime != "" {
 		statement = append(statement, squirrel.GtOrEq{
 			"created_at": sort.StartTime,
 		})
 	}
 
-	if sort.EndTime != "" {
+	if sort.Mode != entities.TaskRangeModeOverlap && sort.EndTime != "" {
 		statement = append(statement, squirrel.LtOrEq{
 			"finished_at": sort.EndTime,
 		})
 	}
 
+	if sort.ProjectID != "" {
+		statement = append(statement, squirrel.Eq{
+			"project_id": sort.ProjectID,
+		})
+	}
+
 	return statement
 }
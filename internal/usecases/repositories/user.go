@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
@@ -30,6 +29,10 @@ func (r *UserRepo) Create(ctx context.Context, user entities.User) (string, erro
 		"passport_number": user.PassportNumber,
 	}
 
+	if user.Timezone != "" {
+		valuesByColumns["timezone"] = user.Timezone
+	}
+
 	sql, args, err := r.Driver.Builder.Insert("users").
 		SetMap(valuesByColumns).
 		Suffix("returning \"user_id\"").
@@ -102,6 +105,10 @@ func (r *UserRepo) Update(ctx context.Context, user entities.User) error {
 		valuesByColumns["passport_number"] = user.PassportNumber
 	}
 
+	if user.Timezone != "" {
+		valuesByColumns["timezone"] = user.Timezone
+	}
+
 	sql, args, err := r.Driver.Builder.Update("users").
 		Where(whereStatement).
 		SetMap(valuesByColumns).
@@ -128,126 +135,149 @@ func (r *UserRepo) Update(ctx context.Context, user entities.User) error {
 	return nil
 }
 
-func (r *UserRepo) GetAll(ctx context.Context, representation entities.UserRepresentation) ([]entities.User, error) {
-	sql, args, err := r.Driver.Builder.Select("user_id", "surname", "name", "patronymic", "address", "passport_number").
+func (r *UserRepo) GetAll(ctx context.Context, representation entities.UserRepresentation) (entities.UserPage, error) {
+	user := entities.User{}
+	dest := []any{&user.ID, &user.Surname, &user.Name, &user.Patronymic, &user.Address, &user.PassportNumber, &user.Timezone}
+
+	builder := r.Driver.Builder.Select("user_id", "surname", "name", "patronymic", "address", "passport_number", "timezone").
 		From("users").
-		Where(r.buildGetAllWhereFilterStatement(representation.Filter)).
+		Where(buildUserFilterStatement(representation.Filter))
+
+	if representation.Filter.Search != "" {
+		builder = builder.
+			Column(squirrel.Expr(fmt.Sprintf("word_similarity(?, %s) as score", userSearchDocument), representation.Filter.Search)).
+			OrderBy("score desc")
+		dest = append(dest, &user.Score)
+	}
+
+	builder = builder.
+		OrderBy(buildUserOrderBy(representation.Sort)...).
 		Limit(setLimitStatement(representation.Pagination.Limit)).
-		Offset(setOffsetStatement(representation.Pagination.Offset)).
-		ToSql()
+		Offset(setOffsetStatement(representation.Pagination.Offset))
+
+	if representation.Pagination.Cursor != "" {
+		builder = builder.Where(squirrel.Gt{"user_id": representation.Pagination.Cursor})
+	}
+
+	sql, args, err := builder.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("repositories: user: getall: tosql: %w", err)
+		return entities.UserPage{}, fmt.Errorf("repositories: user: getall: tosql: %w", err)
 	}
 
 	rows, err := r.Driver.Pool.Query(ctx, sql, args...)
 	if err != nil {
-		return nil, fmt.Errorf("repositories: user: getall: query: %w", err)
+		return entities.UserPage{}, fmt.Errorf("repositories: user: getall: query: %w", err)
 	}
 
 	users := make([]entities.User, 0)
-	user := entities.User{}
 
-	_, err = pgx.ForEachRow(rows, []any{&user.ID, &user.Surname, &user.Name, &user.Patronymic, &user.Address, &user.PassportNumber}, func() error {
+	_, err = pgx.ForEachRow(rows, dest, func() error {
 		users = append(users, user)
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("repositories: user: getall: forEachRow: %w", err)
+		return entities.UserPage{}, fmt.Errorf("repositories: user: getall: forEachRow: %w", err)
 	}
 
 	if len(users) == 0 {
-		return nil, entities.ErrorUsersDoesNotExist
+		return entities.UserPage{}, entities.ErrorUsersDoesNotExist
 	}
 
-	return users, nil
-}
+	page := entities.UserPage{
+		Users:  users,
+		Limit:  setLimitStatement(representation.Pagination.Limit),
+		Offset: setOffsetStatement(representation.Pagination.Offset),
+	}
 
-func (r *UserRepo) buildGetAllWhereFilterStatement(filter entities.UserFilter) squirrel.And {
-	statement := squirrel.And{}
+	if page.Total, err = r.count(ctx, representation.Filter); err != nil {
+		return entities.UserPage{}, fmt.Errorf("repositories: user: getall: %w", err)
+	}
 
-	if filter.ByID != "" {
-		statement = append(statement, squirrel.Eq{
-			"user_id": filter.ByID,
-		})
+	if len(representation.Sort) == 0 && representation.Filter.Search == "" && uint64(len(users)) == page.Limit {
+		page.NextCursor = users[len(users)-1].ID
 	}
 
-	if filter.BySurname != "" {
-		parts := strings.Split(filter.BySurname, ":")
+	return page, nil
+}
 
-		if isOperationEq(parts[0]) {
-			statement = append(statement, squirrel.Eq{
-				"surname": parts[1],
-			})
-		}
+// count is made with the same filter as the page, but regardless of the cursor.
+func (r *UserRepo) count(ctx context.Context, filter entities.UserFilter) (int, error) {
+	sql, args, err := r.Driver.Builder.Select("count(*)").
+		From("users").
+		Where(buildUserFilterStatement(filter)).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("count: tosql: %w", err)
+	}
 
-		if isOperationIlike(parts[0]) {
-			statement = append(statement, squirrel.ILike{
-				"surname": fmt.Sprintf("%%%s%%", parts[1]),
-			})
-		}
+	total := 0
+
+	if err := r.Driver.Pool.QueryRow(ctx, sql, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("count: queryRow: %w", err)
 	}
 
-	if filter.ByName != "" {
-		parts := strings.Split(filter.ByName, ":")
+	return total, nil
+}
 
-		if isOperationEq(parts[0]) {
-			statement = append(statement, squirrel.Eq{
-				"name": parts[1],
-			})
-		}
+// userSearchDocument is the expression of index_users_search, they have to be the same for the index to be used.
+const userSearchDocument = "(surname || ' ' || name || ' ' || patronymic || ' ' || address)"
 
-		if isOperationIlike(parts[0]) {
-			statement = append(statement, squirrel.ILike{
-				"name": fmt.Sprintf("%%%s%%", parts[1]),
-			})
-		}
-	}
+var userOrderColumns = map[string]string{
+	entities.UserOrderByID:             "user_id",
+	entities.UserOrderBySurname:        "surname",
+	entities.UserOrderByName:           "name",
+	entities.UserOrderByPatronymic:     "patronymic",
+	entities.UserOrderByAddress:        "address",
+	entities.UserOrderByPassportNumber: "passport_number",
+}
 
-	if filter.ByPatronymic != "" {
-		parts := strings.Split(filter.ByPatronymic, ":")
+func buildUserOrderBy(sort []entities.UserOrder) []string {
+	orderBy := make([]string, 0, len(sort)+1)
 
-		if isOperationEq(parts[0]) {
-			statement = append(statement, squirrel.Eq{
-				"patronymic": parts[1],
-			})
+	for _, order := range sort {
+		direction := "asc"
+		if order.Desc {
+			direction = "desc"
 		}
 
-		if isOperationIlike(parts[0]) {
-			statement = append(statement, squirrel.ILike{
-				"patronymic": fmt.Sprintf("%%%s%%", parts[1]),
-			})
+		column, ok := userOrderColumns[order.By]
+		if !ok {
+			continue
 		}
+
+		orderBy = append(orderBy, fmt.Sprintf("%s %s", column, direction))
 	}
 
-	if filter.ByAddress != "" {
-		parts := strings.Split(filter.ByAddress, ":")
+	return append(orderBy, "user_id")
+}
 
-		if isOperationEq(parts[0]) {
-			statement = append(statement, squirrel.Eq{
-				"address": parts[1],
-			})
-		}
+func buildUserFilterStatement(filter entities.UserFilter) squirrel.And {
+	statement := squirrel.And{}
 
-		if isOperationIlike(parts[0]) {
-			statement = append(statement, squirrel.ILike{
-				"address": fmt.Sprintf("%%%s%%", parts[1]),
-			})
-		}
+	if filter.ByID != "" {
+		statement = append(statement, squirrel.Eq{
+			"user_id": filter.ByID,
+		})
 	}
 
-	if filter.ByPassportNumber != "" {
-		parts := strings.Split(filter.ByPassportNumber, ":")
+	if filter.Search != "" {
+		statement = append(statement, squirrel.Expr(fmt.Sprintf("? <%% %s", userSearchDocument), filter.Search))
+	}
 
-		if isOperationEq(parts[0]) {
-			statement = append(statement, squirrel.Eq{
-				"passport_number": parts[1],
-			})
-		}
+	columns := []struct {
+		name   string
+		filter entities.Filter
+	}{
+		{"surname", filter.BySurname},
+		{"name", filter.ByName},
+		{"patronymic", filter.ByPatronymic},
+		{"address", filter.ByAddress},
+		{"passport_number", filter.ByPassportNumber},
+	}
 
-		if isOperationIlike(parts[0]) {
-			statement = append(statement, squirrel.ILike{
-				"passport_number": fmt.Sprintf("%%%s%%", parts[1]),
-			})
+	for _, column := range columns {
+		if column.filter.Operation != "" {
+			statement = append(statement, buildFilterStatement(column.name, column.filter))
 		}
 	}
 
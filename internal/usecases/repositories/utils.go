@@ -1,28 +1,51 @@
 package repositories
 
-import "strconv"
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/v1adhope/time-tracker/internal/entities"
+)
 
 const (
 	defaultLimit  = 10
 	defaultOffset = 0
 )
 
-func isOperationEq(target string) bool {
-	if target != "eq" {
-		return false
-	}
-
-	return true
+type executor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
 }
 
-func isOperationIlike(target string) bool {
-	if target != "ilike" {
-		return false
+func buildFilterStatement(column string, filter entities.Filter) squirrel.Sqlizer {
+	switch filter.Operation {
+	case entities.FilterOperationNeq:
+		return squirrel.NotEq{column: filter.Value}
+	case entities.FilterOperationIn:
+		return squirrel.Eq{column: filter.Values}
+	case entities.FilterOperationPrefix:
+		return squirrel.Like{column: escapeLike(filter.Value) + "%"}
+	case entities.FilterOperationSuffix:
+		return squirrel.Like{column: "%" + escapeLike(filter.Value)}
+	case entities.FilterOperationIlike:
+		return squirrel.ILike{column: "%" + escapeLike(filter.Value) + "%"}
+	case entities.FilterOperationNotIlike:
+		return squirrel.NotILike{column: "%" + escapeLike(filter.Value) + "%"}
+	default:
+		return squirrel.Eq{column: filter.Value}
 	}
+}
 
-	return true
+// escapeLike makes wildcards of the value literal, backslash is the default escape character of like.
+func escapeLike(value string) string {
+	return likeReplacer.Replace(value)
 }
 
+var likeReplacer = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 func setLimitStatement(target string) uint64 {
 	if target == "" {
 		return defaultLimit
@@ -48,3 +71,21 @@ func setOffsetStatement(target string) uint64 {
 
 	return value
 }
+
+// loadLocation treats empty timezone as UTC, which is how timestamps were always returned.
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(name)
+}
+
+// nullableTime turns an omitted range bound into NULL, which is unbounded for tstzrange.
+func nullableTime(target string) any {
+	if target == "" {
+		return nil
+	}
+
+	return target
+}
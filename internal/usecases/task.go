@@ -2,21 +2,38 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/v1adhope/time-tracker/internal/entities"
 )
 
+const timesheetsBatchSize = 100
+
 type TaskUsecase struct {
-	TaskRepo TaskRepo
+	TaskRepo      TaskRepo
+	UserRepo      UserRepo
+	runningPolicy string
 }
 
-func NewTask(tr TaskRepo) *TaskUsecase {
-	return &TaskUsecase{tr}
+func NewTask(tr TaskRepo, ur UserRepo, runningPolicy string) *TaskUsecase {
+	return &TaskUsecase{tr, ur, runningPolicy}
 }
 
-func (u *TaskUsecase) Start(ctx context.Context, userID string) error {
+func (u *TaskUsecase) Start(ctx context.Context, task entities.Task) error {
+	var err error
+
 	// INFO: useless return, should refactor
-	_, err := u.TaskRepo.Create(ctx, userID)
+	switch u.runningPolicy {
+	case TaskRunningPolicyStop:
+		_, err = u.TaskRepo.CreateStoppingRunning(ctx, task)
+	default:
+		_, err = u.TaskRepo.Create(ctx, task)
+	}
 	if err != nil {
 		return err
 	}
@@ -24,8 +41,37 @@ func (u *TaskUsecase) Start(ctx context.Context, userID string) error {
 	return nil
 }
 
+func (u *TaskUsecase) Create(ctx context.Context, task entities.Task) (string, error) {
+	createdAt, err := time.Parse(time.RFC3339, task.CreatedAt)
+	if err != nil {
+		return "", fmt.Errorf("usecases: task: create: parse createdAt: %w", err)
+	}
+
+	finishedAt, err := time.Parse(time.RFC3339, task.FinishedAt)
+	if err != nil {
+		return "", fmt.Errorf("usecases: task: create: parse finishedAt: %w", err)
+	}
+
+	if err := validateTaskTimeRange(createdAt, finishedAt); err != nil {
+		return "", err
+	}
+
+	task.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+	task.FinishedAt = finishedAt.UTC().Format(time.RFC3339)
+
+	id, err := u.TaskRepo.CreateFinished(ctx, task)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
 func (u *TaskUsecase) End(ctx context.Context, id string) (string, error) {
 	finishedAt, err := u.TaskRepo.SetFinishedAt(ctx, id)
+	if errors.Is(err, entities.ErrorTaskAlreadyFinished) {
+		return finishedAt, err
+	}
 	if err != nil {
 		return "", err
 	}
@@ -33,11 +79,338 @@ func (u *TaskUsecase) End(ctx context.Context, id string) (string, error) {
 	return finishedAt, nil
 }
 
-func (u *TaskUsecase) GetReportSummaryTime(ctx context.Context, userID string, sort entities.TaskSort) ([]entities.TaskSummary, error) {
-	tasks, err := u.TaskRepo.GetReportSummaryTime(ctx, userID, sort)
+func (u *TaskUsecase) Pause(ctx context.Context, id string) (string, error) {
+	pausedAt, err := u.TaskRepo.Pause(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	return pausedAt, nil
+}
+
+func (u *TaskUsecase) Resume(ctx context.Context, id string) (string, error) {
+	resumedAt, err := u.TaskRepo.Resume(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	return resumedAt, nil
+}
+
+func (u *TaskUsecase) Update(ctx context.Context, task entities.Task) error {
+	if task.CreatedAt == "" && task.FinishedAt == "" && task.Title == "" && task.Description == "" && task.Labels == nil && task.ProjectID == "" {
+		return entities.ErrorNothingToUpdate
+	}
+
+	if task.CreatedAt != "" || task.FinishedAt != "" {
+		current, err := u.TaskRepo.Get(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+
+		if task.FinishedAt != "" && current.FinishedAt == "" {
+			return entities.ErrorTaskIsRunning
+		}
+
+		if task.CreatedAt == "" {
+			task.CreatedAt = current.CreatedAt
+		}
+
+		createdAt, err := time.Parse(time.RFC3339, task.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("usecases: task: update: parse createdAt: %w", err)
+		}
+
+		task.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+
+		finishedAt := time.Now()
+
+		if task.FinishedAt != "" {
+			finishedAt, err = time.Parse(time.RFC3339, task.FinishedAt)
+			if err != nil {
+				return fmt.Errorf("usecases: task: update: parse finishedAt: %w", err)
+			}
+
+			task.FinishedAt = finishedAt.UTC().Format(time.RFC3339)
+		} else if current.FinishedAt != "" {
+			finishedAt, err = time.Parse(time.RFC3339, current.FinishedAt)
+			if err != nil {
+				return fmt.Errorf("usecases: task: update: parse current finishedAt: %w", err)
+			}
+		}
+
+		if err := validateTaskTimeRange(createdAt, finishedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := u.TaskRepo.Update(ctx, task); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *TaskUsecase) Delete(ctx context.Context, id string) error {
+	if err := u.TaskRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *TaskUsecase) Get(ctx context.Context, id string) (entities.Task, error) {
+	task, err := u.TaskRepo.Get(ctx, id)
+	if err != nil {
+		return entities.Task{}, err
+	}
+
+	return task, nil
+}
+
+func (u *TaskUsecase) GetOverlaps(ctx context.Context, userID string) ([]entities.TaskOverlap, error) {
+	overlaps, err := u.TaskRepo.GetOverlaps(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range overlaps {
+		overlaps[i].OverlapTime = formatSummaryTime(overlaps[i].OverlapDuration, entities.DurationRoundingMinute)
+	}
+
+	return overlaps, nil
+}
+
+func (u *TaskUsecase) GetReportSummaryTime(ctx context.Context, userID string, sort entities.TaskSort, pagination entities.TaskPagination, duration entities.DurationRepresentation) (entities.TaskSummaryPage, error) {
+	sort, err := u.withUserTimezone(ctx, userID, sort)
+	if err != nil {
+		return entities.TaskSummaryPage{}, err
+	}
+
+	page, err := u.TaskRepo.GetReportSummaryTime(ctx, userID, sort, pagination)
+	if err != nil {
+		return entities.TaskSummaryPage{}, err
+	}
+
+	for i := range page.Tasks {
+		setTaskSummaryTime(&page.Tasks[i], duration)
+	}
+
+	return page, nil
+}
+
+func (u *TaskUsecase) GetReportSummaryTimeByProject(ctx context.Context, userID string, sort entities.TaskSort) ([]entities.ProjectSummary, error) {
+	projects, err := u.TaskRepo.GetReportSummaryTimeByProject(ctx, userID, sort)
 	if err != nil {
 		return nil, err
 	}
 
-	return tasks, nil
+	for i := range projects {
+		projects[i].SummaryTime = formatSummaryTime(projects[i].SummaryDuration, entities.DurationRoundingMinute)
+	}
+
+	return projects, nil
+}
+
+func (u *TaskUsecase) GetReportSummaryTimeByPeriod(ctx context.Context, userID, groupBy string, sort entities.TaskSort) (entities.TaskPeriodReport, error) {
+	sort, err := u.withUserTimezone(ctx, userID, sort)
+	if err != nil {
+		return entities.TaskPeriodReport{}, err
+	}
+
+	report, err := u.TaskRepo.GetReportSummaryTimeByPeriod(ctx, userID, groupBy, sort)
+	if err != nil {
+		return entities.TaskPeriodReport{}, err
+	}
+
+	for i := range report.Periods {
+		report.Periods[i].SummaryTime = formatSummaryTime(report.Periods[i].SummaryDuration, entities.DurationRoundingMinute)
+	}
+
+	report.SummaryTime = formatSummaryTime(report.SummaryDuration, entities.DurationRoundingMinute)
+
+	return report, nil
+}
+
+func (u *TaskUsecase) GetReportSummaryTimeByUsers(ctx context.Context, representation entities.UserSummaryRepresentation) ([]entities.UserSummary, error) {
+	users, err := u.TaskRepo.GetReportSummaryTimeByUsers(ctx, representation)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range users {
+		users[i].SummaryTime = formatSummaryTime(users[i].SummaryDuration, entities.DurationRoundingMinute)
+	}
+
+	return users, nil
+}
+
+// GetReportTimesheets collects tasks of every user from the population in chronological order.
+func (u *TaskUsecase) GetReportTimesheets(ctx context.Context, representation entities.UserSummaryRepresentation) ([]entities.TaskTimesheet, error) {
+	users, err := u.getTimesheetsPopulation(ctx, representation)
+	if err != nil {
+		return nil, err
+	}
+
+	timesheets := make([]entities.TaskTimesheet, 0, len(users))
+
+	for _, user := range users {
+		sort := representation.Sort
+
+		if sort.Timezone == "" {
+			sort.Timezone = user.Timezone
+		}
+
+		page, err := u.TaskRepo.GetReportSummaryTime(ctx, user.UserID, sort, entities.TaskPagination{})
+		if err != nil && !errors.Is(err, entities.ErrorNoAnyTasksForThisUser) {
+			return nil, err
+		}
+
+		slices.SortStableFunc(page.Tasks, func(a, b entities.TaskSummary) int {
+			return strings.Compare(a.CreatedAt, b.CreatedAt)
+		})
+
+		for i := range page.Tasks {
+			setTaskSummaryTime(&page.Tasks[i], entities.DurationRepresentation{})
+		}
+
+		timesheets = append(timesheets, entities.TaskTimesheet{
+			User: entities.User{
+				ID:       user.UserID,
+				Surname:  user.Surname,
+				Name:     user.Name,
+				Timezone: user.Timezone,
+			},
+			Tasks: page.Tasks,
+		})
+	}
+
+	return timesheets, nil
+}
+
+// getTimesheetsPopulation respects explicit pagination, otherwise the whole population is fetched
+// batch by batch, so the workbook isn't cut by the default limit.
+func (u *TaskUsecase) getTimesheetsPopulation(ctx context.Context, representation entities.UserSummaryRepresentation) ([]entities.UserSummary, error) {
+	if representation.Pagination != (entities.UserPagination{}) {
+		return u.TaskRepo.GetReportSummaryTimeByUsers(ctx, representation)
+	}
+
+	population := make([]entities.UserSummary, 0)
+	representation.Pagination.Limit = strconv.Itoa(timesheetsBatchSize)
+
+	for {
+		users, err := u.TaskRepo.GetReportSummaryTimeByUsers(ctx, representation)
+		if errors.Is(err, entities.ErrorUsersDoesNotExist) && len(population) != 0 {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		population = append(population, users...)
+
+		if len(users) < timesheetsBatchSize {
+			break
+		}
+
+		representation.Pagination.Offset = strconv.Itoa(len(population))
+	}
+
+	return population, nil
+}
+
+// GetReportTimesheet is a timesheet of a single user, a user without tasks in the range gets an empty one.
+func (u *TaskUsecase) GetReportTimesheet(ctx context.Context, userID string, sort entities.TaskSort) (entities.TaskTimesheet, error) {
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return entities.TaskTimesheet{}, err
+	}
+
+	if sort.Timezone == "" {
+		sort.Timezone = user.Timezone
+	}
+
+	page, err := u.TaskRepo.GetReportSummaryTime(ctx, userID, sort, entities.TaskPagination{})
+	if err != nil && !errors.Is(err, entities.ErrorNoAnyTasksForThisUser) {
+		return entities.TaskTimesheet{}, err
+	}
+
+	slices.SortStableFunc(page.Tasks, func(a, b entities.TaskSummary) int {
+		return strings.Compare(a.CreatedAt, b.CreatedAt)
+	})
+
+	for i := range page.Tasks {
+		setTaskSummaryTime(&page.Tasks[i], entities.DurationRepresentation{})
+	}
+
+	return entities.TaskTimesheet{
+		User:  user,
+		Tasks: page.Tasks,
+	}, nil
+}
+
+// ExportReportSummaryTime rounds summary time of every record and the total the same way, only human format
+// is exported so the duration format of the representation isn't used.
+func (u *TaskUsecase) ExportReportSummaryTime(ctx context.Context, userID string, sort entities.TaskSort, duration entities.DurationRepresentation, fn func(record entities.TaskSummaryRecord) error) error {
+	sort, err := u.withUserTimezone(ctx, userID, sort)
+	if err != nil {
+		return err
+	}
+
+	if err := u.TaskRepo.EachReportSummaryTime(ctx, userID, sort, func(record entities.TaskSummaryRecord) error {
+		setTaskSummaryTime(&record.Task, entities.DurationRepresentation{
+			Rounding: duration.Rounding,
+		})
+		record.TotalSummaryTime = formatSummaryTime(record.TotalSummaryDuration, duration.Rounding)
+
+		return fn(record)
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *TaskUsecase) getUser(ctx context.Context, userID string) (entities.User, error) {
+	page, err := u.UserRepo.GetAll(ctx, entities.UserRepresentation{
+		Pagination: entities.UserPagination{
+			Limit: "1",
+		},
+		Filter: entities.UserFilter{
+			ByID: userID,
+		},
+	})
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	return page.Users[0], nil
+}
+
+// withUserTimezone falls back to the user's timezone when the report isn't asked in a specific one.
+func (u *TaskUsecase) withUserTimezone(ctx context.Context, userID string, sort entities.TaskSort) (entities.TaskSort, error) {
+	if sort.Timezone != "" {
+		return sort, nil
+	}
+
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return entities.TaskSort{}, err
+	}
+
+	sort.Timezone = user.Timezone
+
+	return sort, nil
+}
+
+func validateTaskTimeRange(createdAt, finishedAt time.Time) error {
+	if finishedAt.After(time.Now()) || createdAt.After(time.Now()) {
+		return entities.ErrorTaskInFuture
+	}
+
+	if !finishedAt.After(createdAt) {
+		return entities.ErrorTaskInvalidTimeRange
+	}
+
+	return nil
 }
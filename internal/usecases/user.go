@@ -39,13 +39,13 @@ func (u *UserUsecase) Update(ctx context.Context, user entities.User) error {
 	return nil
 }
 
-func (u *UserUsecase) GetAll(ctx context.Context, representation entities.UserRepresentation) ([]entities.User, error) {
-	users, err := u.userRepo.GetAll(ctx, representation)
+func (u *UserUsecase) GetAll(ctx context.Context, representation entities.UserRepresentation) (entities.UserPage, error) {
+	page, err := u.userRepo.GetAll(ctx, representation)
 	if err != nil {
-		return nil, err
+		return entities.UserPage{}, err
 	}
 
-	return users, nil
+	return page, nil
 }
 
 func (u *UserUsecase) Get(ctx context.Context, passportNumber string) (entities.User, error) {
@@ -0,0 +1,4 @@
+alter table if exists tasks
+  drop column if exists title,
+  drop column if exists description,
+  drop column if exists labels;
@@ -0,0 +1,4 @@
+alter table tasks
+  add column if not exists title varchar(255) not null default '',
+  add column if not exists description text not null default '',
+  add column if not exists labels varchar(64)[] not null default '{}';
@@ -0,0 +1,4 @@
+alter table if exists tasks
+  drop column if exists project_id;
+
+drop table if exists projects cascade;
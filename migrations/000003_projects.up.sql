@@ -0,0 +1,14 @@
+create table if not exists projects (
+  project_id uuid default uuid6(),
+  name varchar(255) not null,
+  description text not null default '',
+
+  constraint pk_projects_project_id primary key(project_id),
+  constraint uq_projects_name unique(name)
+);
+
+alter table tasks
+  add column if not exists project_id uuid,
+  add constraint fk_tasks_projects_project_id foreign key(project_id) references projects(project_id) on delete set null;
+
+create index if not exists index_tasks_project_id on tasks(project_id);
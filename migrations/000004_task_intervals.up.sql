@@ -0,0 +1,16 @@
+create table if not exists task_intervals (
+  interval_id uuid default uuid6(),
+  task_id uuid not null,
+  started_at timestamp not null,
+  finished_at timestamp,
+
+  constraint pk_task_intervals_interval_id primary key(interval_id),
+  constraint fk_task_intervals_tasks_task_id foreign key(task_id) references tasks(task_id) on delete cascade
+);
+
+create index if not exists index_task_intervals_task_id on task_intervals(task_id);
+
+create unique index if not exists uidx_task_intervals_task_id_running on task_intervals(task_id) where finished_at is null;
+
+insert into task_intervals (task_id, started_at, finished_at)
+select task_id, created_at, finished_at from tasks;
@@ -0,0 +1 @@
+drop index if exists uidx_tasks_user_id_running;
@@ -0,0 +1,17 @@
+update tasks as t
+set finished_at = (
+  select min(n.created_at) from tasks as n
+  where n.user_id = t.user_id and n.finished_at is null and (n.created_at, n.task_id) > (t.created_at, t.task_id)
+)
+where t.finished_at is null
+  and exists (
+    select 1 from tasks as n
+    where n.user_id = t.user_id and n.finished_at is null and (n.created_at, n.task_id) > (t.created_at, t.task_id)
+  );
+
+update task_intervals as i
+set finished_at = t.finished_at
+from tasks as t
+where i.task_id = t.task_id and i.finished_at is null and t.finished_at is not null;
+
+create unique index if not exists uidx_tasks_user_id_running on tasks(user_id) where finished_at is null;
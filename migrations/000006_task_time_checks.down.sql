@@ -0,0 +1,5 @@
+alter table if exists task_intervals
+  drop constraint if exists chk_task_intervals_finished_at;
+
+alter table if exists tasks
+  drop constraint if exists chk_tasks_finished_at;
@@ -0,0 +1,5 @@
+alter table tasks
+  add constraint chk_tasks_finished_at check (finished_at is null or finished_at >= created_at);
+
+alter table task_intervals
+  add constraint chk_task_intervals_finished_at check (finished_at is null or finished_at >= started_at);
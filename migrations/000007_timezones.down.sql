@@ -0,0 +1,10 @@
+alter table if exists task_intervals
+  alter column started_at type timestamp using started_at at time zone 'UTC',
+  alter column finished_at type timestamp using finished_at at time zone 'UTC';
+
+alter table if exists tasks
+  alter column created_at type timestamp using created_at at time zone 'UTC',
+  alter column finished_at type timestamp using finished_at at time zone 'UTC';
+
+alter table if exists users
+  drop column if exists timezone;
@@ -0,0 +1,10 @@
+alter table users
+  add column if not exists timezone varchar(64) not null default 'UTC';
+
+alter table tasks
+  alter column created_at type timestamptz using created_at at time zone 'UTC',
+  alter column finished_at type timestamptz using finished_at at time zone 'UTC';
+
+alter table task_intervals
+  alter column started_at type timestamptz using started_at at time zone 'UTC',
+  alter column finished_at type timestamptz using finished_at at time zone 'UTC';
@@ -0,0 +1,3 @@
+drop index if exists index_users_search;
+
+drop extension if exists pg_trgm;
@@ -0,0 +1,3 @@
+create extension if not exists pg_trgm;
+
+create index if not exists index_users_search on users using gin ((surname || ' ' || name || ' ' || patronymic || ' ' || address) gin_trgm_ops);
@@ -0,0 +1,11 @@
+create table if not exists credentials (
+  user_id uuid,
+  login varchar(255) not null,
+  password_hash varchar(255) not null,
+  is_admin boolean not null default false,
+  version integer not null default 1,
+
+  constraint pk_credentials_user_id primary key(user_id),
+  constraint uq_credentials_login unique(login),
+  constraint fk_credentials_users_user_id foreign key(user_id) references users(user_id) on delete cascade
+);
@@ -12,6 +12,7 @@ type Config struct {
 
 type Logger interface {
 	Info(msg string)
+	Warn(msg string)
 	Track(resource, ip string, status int)
 	Debug(err error)
 	Error(err error)
@@ -50,6 +51,10 @@ func (l *Log) Info(msg string) {
 	l.Logger.Info().Msg(msg)
 }
 
+func (l *Log) Warn(msg string) {
+	l.Logger.Warn().Msg(msg)
+}
+
 func (l *Log) Track(resource, ip string, status int) {
 	l.Logger.Info().
 		Str("resource", resource).
@@ -0,0 +1,175 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+
+	defaultTTL = time.Hour
+
+	// feedAudience tells feed tokens apart, they never expire and are useless as access tokens.
+	feedAudience = "feed"
+
+	// minSecretLength is the HS256 key size, shorter secrets (placeholders among them) are brute-forceable.
+	minSecretLength = 32
+)
+
+type Config struct {
+	Algorithm      string `koanf:"APP_AUTH_ALGORITHM"`
+	Secret         string `koanf:"APP_AUTH_SECRET"`
+	PrivateKeyPath string `koanf:"APP_AUTH_PRIVATE_KEY_PATH"`
+	PublicKeyPath  string `koanf:"APP_AUTH_PUBLIC_KEY_PATH"`
+	TTL            int64  `koanf:"APP_AUTH_TOKEN_TTL"`
+}
+
+// claims binds a token to the version of the subject credentials, so replacing them revokes issued tokens.
+type claims struct {
+	jwt.RegisteredClaims
+	Version int `json:"ver"`
+}
+
+// Manager issues and verifies JWT carrying the subject and version of its credentials besides times.
+type Manager struct {
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+	ttl       time.Duration
+}
+
+func New(cfg *Config) (*Manager, error) {
+	manager := &Manager{
+		ttl: defaultTTL,
+	}
+
+	if cfg.TTL > 0 {
+		manager.ttl = time.Duration(cfg.TTL) * time.Second
+	}
+
+	switch cfg.Algorithm {
+	case AlgorithmHS256, "":
+		if len(cfg.Secret) < minSecretLength {
+			return nil, fmt.Errorf("token: new: secret should be at least %d bytes", minSecretLength)
+		}
+
+		manager.method = jwt.SigningMethodHS256
+		manager.signKey = []byte(cfg.Secret)
+		manager.verifyKey = []byte(cfg.Secret)
+	case AlgorithmRS256:
+		pem, err := os.ReadFile(cfg.PrivateKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("token: new: read private key: %w", err)
+		}
+
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("token: new: parse private key: %w", err)
+		}
+
+		manager.method = jwt.SigningMethodRS256
+		manager.signKey = privateKey
+		manager.verifyKey = &privateKey.PublicKey
+
+		if cfg.PublicKeyPath != "" {
+			pem, err := os.ReadFile(cfg.PublicKeyPath)
+			if err != nil {
+				return nil, fmt.Errorf("token: new: read public key: %w", err)
+			}
+
+			if manager.verifyKey, err = jwt.ParseRSAPublicKeyFromPEM(pem); err != nil {
+				return nil, fmt.Errorf("token: new: parse public key: %w", err)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("token: new: unknown algorithm %q", cfg.Algorithm)
+	}
+
+	return manager, nil
+}
+
+func (m *Manager) Issue(subject string, version int) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+
+	signed, err := jwt.NewWithClaims(m.method, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Version: version,
+	}).SignedString(m.signKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("token: issue: %w", err)
+	}
+
+	return signed, expiresAt, nil
+}
+
+// Parse accepts only tokens signed with the configured algorithm and having expiration time,
+// it reports the subject and version of its credentials.
+func (m *Manager) Parse(token string) (string, int, error) {
+	parsed, err := m.parse(token, jwt.WithExpirationRequired())
+	if err != nil {
+		return "", 0, fmt.Errorf("token: parse: %w", err)
+	}
+
+	if len(parsed.Audience) != 0 {
+		return "", 0, errors.New("token: parse: audience is unexpected")
+	}
+
+	return parsed.Subject, parsed.Version, nil
+}
+
+// IssueFeed signs a token for feeds fetched by clients unable to send headers (calendars among them),
+// it lives as long as the credentials version.
+func (m *Manager) IssueFeed(subject string, version int) (string, error) {
+	signed, err := jwt.NewWithClaims(m.method, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:  subject,
+			Audience: jwt.ClaimStrings{feedAudience},
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+		Version: version,
+	}).SignedString(m.signKey)
+	if err != nil {
+		return "", fmt.Errorf("token: issueFeed: %w", err)
+	}
+
+	return signed, nil
+}
+
+// ParseFeed accepts only tokens issued by IssueFeed.
+func (m *Manager) ParseFeed(token string) (string, int, error) {
+	parsed, err := m.parse(token, jwt.WithAudience(feedAudience))
+	if err != nil {
+		return "", 0, fmt.Errorf("token: parseFeed: %w", err)
+	}
+
+	return parsed.Subject, parsed.Version, nil
+}
+
+func (m *Manager) parse(token string, options ...jwt.ParserOption) (claims, error) {
+	parsed := claims{}
+
+	options = append(options, jwt.WithValidMethods([]string{m.method.Alg()}))
+
+	if _, err := jwt.ParseWithClaims(token, &parsed, func(*jwt.Token) (any, error) {
+		return m.verifyKey, nil
+	}, options...); err != nil {
+		return claims{}, err
+	}
+
+	if parsed.Subject == "" {
+		return claims{}, errors.New("subject is empty")
+	}
+
+	return parsed, nil
+}